@@ -0,0 +1,151 @@
+package assets
+
+import (
+	"math/big"
+	"sort"
+)
+
+// BitcoinSlice attaches sort.Interface to []Bitcoin, sorting ascending by Compare
+type BitcoinSlice []Bitcoin
+
+func (slice BitcoinSlice) Len() int           { return len(slice) }
+func (slice BitcoinSlice) Less(i, j int) bool { return slice[i].Compare(slice[j]) < 0 }
+func (slice BitcoinSlice) Swap(i, j int)      { slice[i], slice[j] = slice[j], slice[i] }
+
+// EtherSlice attaches sort.Interface to []Ether, sorting ascending by Compare
+type EtherSlice []Ether
+
+func (slice EtherSlice) Len() int           { return len(slice) }
+func (slice EtherSlice) Less(i, j int) bool { return slice[i].Compare(slice[j]) < 0 }
+func (slice EtherSlice) Swap(i, j int)      { slice[i], slice[j] = slice[j], slice[i] }
+
+// USDSlice attaches sort.Interface to []USD, sorting ascending by Compare
+type USDSlice []USD
+
+func (slice USDSlice) Len() int           { return len(slice) }
+func (slice USDSlice) Less(i, j int) bool { return slice[i].Compare(slice[j]) < 0 }
+func (slice USDSlice) Swap(i, j int)      { slice[i], slice[j] = slice[j], slice[i] }
+
+// SumBitcoin total of the bitcoins, returning an OverflowError if the total does not fit in bitcoin
+func SumBitcoin(bitcoins []Bitcoin) (Bitcoin, error) {
+	return Sum(bitcoins)
+}
+
+// MinBitcoin smallest of the bitcoins, the first one when several are equal
+func MinBitcoin(bitcoins []Bitcoin) (Bitcoin, error) {
+	return Min(bitcoins)
+}
+
+// MaxBitcoin largest of the bitcoins, the first one when several are equal
+func MaxBitcoin(bitcoins []Bitcoin) (Bitcoin, error) {
+	return Max(bitcoins)
+}
+
+// MeanBitcoin average of the bitcoins rounded half up to a satoshi, the total may exceed an int64
+func MeanBitcoin(bitcoins []Bitcoin) (Bitcoin, error) {
+	return Mean(bitcoins, RoundHalfUp)
+}
+
+// MedianBitcoin middle of the bitcoins, the mean of the middle two rounded half up for an even count
+func MedianBitcoin(bitcoins []Bitcoin) (Bitcoin, error) {
+	return Median(bitcoins, RoundHalfUp)
+}
+
+// SumEther total of the ether
+func SumEther(ethers []Ether) (Ether, error) {
+	return Sum(ethers)
+}
+
+// MinEther smallest of the ether, the first one when several are equal
+func MinEther(ethers []Ether) (Ether, error) {
+	return Min(ethers)
+}
+
+// MaxEther largest of the ether, the first one when several are equal
+func MaxEther(ethers []Ether) (Ether, error) {
+	return Max(ethers)
+}
+
+// MeanEther average of the ether, truncating fractions of a wei
+func MeanEther(ethers []Ether) (Ether, error) {
+	return Mean(ethers, RoundTruncate)
+}
+
+// MedianEther middle of the ether, the mean of the middle two truncated to a wei for an even count
+func MedianEther(ethers []Ether) (Ether, error) {
+	return Median(ethers, RoundTruncate)
+}
+
+// SumUSD total of the usd, returning an OverflowError if the total does not fit in USD
+func SumUSD(usds []USD) (USD, error) {
+	return Sum(usds)
+}
+
+// MinUSD smallest of the usd, the first one when several are equal
+func MinUSD(usds []USD) (USD, error) {
+	return Min(usds)
+}
+
+// MaxUSD largest of the usd, the first one when several are equal
+func MaxUSD(usds []USD) (USD, error) {
+	return Max(usds)
+}
+
+// MeanUSD average of the usd rounded half up to a cent, the total may exceed an int64
+func MeanUSD(usds []USD) (USD, error) {
+	return Mean(usds, RoundHalfUp)
+}
+
+// MedianUSD middle of the usd, the mean of the middle two rounded half up for an even count
+func MedianUSD(usds []USD) (USD, error) {
+	return Median(usds, RoundHalfUp)
+}
+
+func sumAmounts(currency Currency, amounts []Amount) Amount {
+	total := ZeroAmount(currency)
+	for _, amount := range amounts {
+		total = total.add(amount)
+	}
+	return total
+}
+
+// index of the first smallest amount for a direction of -1 or the first largest for 1
+func extremeAmount(currency Currency, name string, amounts []Amount, direction int) (int, error) {
+	if len(amounts) == 0 {
+		return 0, newEmptyAggregateError(currency, name)
+	}
+	index := 0
+	for i := 1; i < len(amounts); i++ {
+		if amounts[i].compare(amounts[index]) == direction {
+			index = i
+		}
+	}
+	return index, nil
+}
+
+func meanAmount(currency Currency, amounts []Amount, mode RoundingMode) (Amount, error) {
+	if len(amounts) == 0 {
+		return Amount{}, newEmptyAggregateError(currency, "mean")
+	}
+	total := sumAmounts(currency, amounts)
+	return total.withValue(quoRound(total.minorUnits(), big.NewInt(int64(len(amounts))), mode)), nil
+}
+
+func medianAmount(currency Currency, amounts []Amount, mode RoundingMode) (Amount, error) {
+	if len(amounts) == 0 {
+		return Amount{}, newEmptyAggregateError(currency, "median")
+	}
+	sorted := append([]Amount(nil), amounts...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].compare(sorted[j]) < 0
+	})
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[middle], nil
+	}
+	return meanAmount(currency, sorted[middle-1:middle+1], mode)
+}
+
+func newEmptyAggregateError(currency Currency, name string) ConversionError {
+	return ConversionError{message: "No " + currency.Code + " to take the " + name + " of"}
+}
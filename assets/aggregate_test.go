@@ -0,0 +1,114 @@
+package assets
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"testing"
+)
+
+func testBitcoins(values ...int64) []Bitcoin {
+	bitcoins := make([]Bitcoin, len(values))
+	for i, value := range values {
+		bitcoins[i] = NewBitcoinFromInt(value)
+	}
+	return bitcoins
+}
+
+func TestBitcoinAggregates(t *testing.T) {
+	bitcoins := testBitcoins(500, -200, 300, 100)
+	sum, err := SumBitcoin(bitcoins)
+	if err != nil || sum.GetIntValue() != 700 {
+		t.Errorf("Invalid sum %v %v", sum, err)
+	}
+	minimum, _ := MinBitcoin(bitcoins)
+	maximum, _ := MaxBitcoin(bitcoins)
+	if minimum.GetIntValue() != -200 || maximum.GetIntValue() != 500 {
+		t.Errorf("Invalid min %d or max %d", minimum.GetIntValue(), maximum.GetIntValue())
+	}
+	mean, _ := MeanBitcoin(bitcoins)
+	if mean.GetIntValue() != 175 {
+		t.Errorf("Invalid mean %d", mean.GetIntValue())
+	}
+	median, _ := MedianBitcoin(bitcoins)
+	if median.GetIntValue() != 200 {
+		t.Errorf("Invalid median %d", median.GetIntValue())
+	}
+	oddMedian, _ := MedianBitcoin(bitcoins[:3])
+	if oddMedian.GetIntValue() != 300 {
+		t.Errorf("Invalid odd median %d", oddMedian.GetIntValue())
+	}
+	if bitcoins[0].GetIntValue() != 500 {
+		t.Error("Median reordered the input")
+	}
+	empty, err := SumBitcoin(nil)
+	if err != nil || !empty.IsZero() {
+		t.Errorf("Invalid empty sum %v %v", empty, err)
+	}
+}
+
+func TestBitcoinAggregateOverflow(t *testing.T) {
+	bitcoins := testBitcoins(math.MaxInt64, math.MaxInt64-1)
+	if _, err := SumBitcoin(bitcoins); !errors.As(err, &OverflowError{}) {
+		t.Errorf("Expected overflow error, got %v", err)
+	}
+	mean, err := MeanBitcoin(bitcoins)
+	if err != nil || mean.GetIntValue() != math.MaxInt64 {
+		t.Errorf("Invalid mean of large values %v %v", mean, err)
+	}
+	usds := []USD{NewUSDFromInt(math.MinInt64), NewUSDFromInt(-1)}
+	if _, err := SumUSD(usds); !errors.As(err, &OverflowError{}) {
+		t.Errorf("Expected usd overflow error, got %v", err)
+	}
+}
+
+func TestEtherAndUSDAggregates(t *testing.T) {
+	ethers := []Ether{NewEtherFromInt(1), NewEtherFromInt(2)}
+	if etherSum, err := SumEther(ethers); err != nil || etherSum.GetIntValue() != 3 {
+		t.Errorf("Invalid ether sum %v %v", etherSum, err)
+	}
+	etherMean, _ := MeanEther(ethers)
+	etherMedian, _ := MedianEther(ethers)
+	if etherMean.GetIntValue() != 1 || etherMedian.GetIntValue() != 1 {
+		t.Errorf("Expected ether mean and median to truncate, got %d %d", etherMean.GetIntValue(), etherMedian.GetIntValue())
+	}
+	usds := []USD{NewUSDFromInt(1), NewUSDFromInt(2)}
+	usdMean, _ := MeanUSD(usds)
+	usdMax, _ := MaxUSD(usds)
+	if usdMean.GetIntValue() != 2 || usdMax.GetIntValue() != 2 {
+		t.Errorf("Invalid usd mean %d or max %d", usdMean.GetIntValue(), usdMax.GetIntValue())
+	}
+}
+
+func TestAggregateEmpty(t *testing.T) {
+	if _, err := MinBitcoin(nil); err == nil {
+		t.Error("Expected error for min of no bitcoin")
+	}
+	if _, err := MaxEther(nil); err == nil {
+		t.Error("Expected error for max of no ether")
+	}
+	if _, err := MeanUSD(nil); err == nil {
+		t.Error("Expected error for mean of no usd")
+	}
+	if _, err := MedianUSD([]USD{}); err == nil {
+		t.Error("Expected error for median of no usd")
+	}
+}
+
+func TestSortAdapters(t *testing.T) {
+	bitcoins := testBitcoins(3, -1, 2)
+	sort.Sort(BitcoinSlice(bitcoins))
+	if bitcoins[0].GetIntValue() != -1 || bitcoins[2].GetIntValue() != 3 {
+		t.Errorf("Bitcoin not sorted %v", bitcoins)
+	}
+	ethers := []Ether{NewEtherFromInt(2), NewEtherFromInt(1)}
+	sort.Sort(EtherSlice(ethers))
+	if ethers[0].GetIntValue() != 1 {
+		t.Errorf("Ether not sorted %v", ethers)
+	}
+	usds := []USD{NewUSDFromInt(5), NewUSDFromInt(-5)}
+	sort.Sort(sort.Reverse(USDSlice(usds)))
+	if usds[0].GetIntValue() != 5 {
+		t.Errorf("USD not reverse sorted %v", usds)
+	}
+}
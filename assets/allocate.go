@@ -0,0 +1,97 @@
+package assets
+
+import (
+	"math/big"
+	"sort"
+	"strconv"
+)
+
+// Allocate split the amount in proportion to the ratios using the largest remainder method, the parts
+// always sum to the amount. Leftover minor units go to the parts with the largest remainders, ties
+// going to the earlier part
+func (amount Amount) Allocate(ratios ...int64) ([]Amount, error) {
+	if len(ratios) == 0 {
+		return nil, ConversionError{message: "No ratios to allocate " + amount.currency.Code + " by"}
+	}
+	total := new(big.Int)
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, ConversionError{message: "Negative ratio [" + strconv.FormatInt(ratio, 10) + "] allocating " + amount.currency.Code}
+		}
+		total.Add(total, big.NewInt(ratio))
+	}
+	if total.Sign() == 0 {
+		return nil, ConversionError{message: "Ratios allocating " + amount.currency.Code + " sum to zero"}
+	}
+	// allocate the magnitude so negative amounts split symmetrically with positive ones
+	magnitude := new(big.Int).Abs(amount.minorUnits())
+	parts := make([]*big.Int, len(ratios))
+	remainders := make([]*big.Int, len(ratios))
+	leftover := new(big.Int).Set(magnitude)
+	for i, ratio := range ratios {
+		parts[i], remainders[i] = new(big.Int).QuoRem(new(big.Int).Mul(magnitude, big.NewInt(ratio)), total, new(big.Int))
+		leftover.Sub(leftover, parts[i])
+	}
+	order := make([]int, len(ratios))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return remainders[order[i]].Cmp(remainders[order[j]]) > 0
+	})
+	// leftover is less than the number of parts, each remainder contributed under one minor unit
+	for i := int64(0); i < leftover.Int64(); i++ {
+		parts[order[i]].Add(parts[order[i]], big.NewInt(1))
+	}
+	allocated := make([]Amount, len(parts))
+	for i, part := range parts {
+		if amount.minorUnits().Sign() < 0 {
+			part.Neg(part)
+		}
+		allocated[i] = amount.withValue(part)
+	}
+	return allocated, nil
+}
+
+// SplitEvenly split the amount into n parts that differ by at most one minor unit and sum to the amount,
+// the earlier parts getting the extra units
+func (amount Amount) SplitEvenly(n int) ([]Amount, error) {
+	if n <= 0 {
+		return nil, ConversionError{message: "Cannot split " + amount.currency.Code + " into [" + strconv.Itoa(n) + "] parts"}
+	}
+	ratios := make([]int64, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return amount.Allocate(ratios...)
+}
+
+// Allocate split the bitcoin in proportion to the ratios without losing or inventing satoshis
+func (bitcoin bitcoinStruct) Allocate(ratios ...int64) ([]Bitcoin, error) {
+	return Allocate[Bitcoin](bitcoin, ratios...)
+}
+
+// SplitEvenly split the bitcoin into n parts differing by at most one satoshi
+func (bitcoin bitcoinStruct) SplitEvenly(n int) ([]Bitcoin, error) {
+	return SplitEvenly[Bitcoin](bitcoin, n)
+}
+
+// Allocate split the ether in proportion to the ratios without losing or inventing wei
+func (ether etherStruct) Allocate(ratios ...int64) ([]Ether, error) {
+	return Allocate[Ether](ether, ratios...)
+}
+
+// SplitEvenly split the ether into n parts differing by at most one wei
+func (ether etherStruct) SplitEvenly(n int) ([]Ether, error) {
+	return SplitEvenly[Ether](ether, n)
+}
+
+// Allocate split the usd in proportion to the ratios without losing or inventing cents
+func (usd usdStruct) Allocate(ratios ...int64) ([]USD, error) {
+	return Allocate[USD](usd, ratios...)
+}
+
+// SplitEvenly split the usd into n parts differing by at most one cent
+func (usd usdStruct) SplitEvenly(n int) ([]USD, error) {
+	return SplitEvenly[USD](usd, n)
+}
@@ -0,0 +1,86 @@
+package assets
+
+import "testing"
+
+func TestAllocateSumsExactly(t *testing.T) {
+	usd, _ := NewUSDFromString("100.00")
+	parts, err := usd.Allocate(1, 1, 1)
+	if err != nil {
+		t.Fatalf("Error allocating %v", err)
+	}
+	expected := []int64{3334, 3333, 3333}
+	for i, part := range parts {
+		if part.GetIntValue() != expected[i] {
+			t.Errorf("Invalid part %d %d, expected %d", i, part.GetIntValue(), expected[i])
+		}
+	}
+	btc := NewBitcoinFromInt(5)
+	btcParts, _ := btc.Allocate(3, 7)
+	// 1.5 and 3.5 satoshis, the tie goes to the first part
+	if btcParts[0].GetIntValue() != 2 || btcParts[1].GetIntValue() != 3 {
+		t.Errorf("Invalid bitcoin parts %d %d", btcParts[0].GetIntValue(), btcParts[1].GetIntValue())
+	}
+}
+
+func TestAllocateLargestRemainder(t *testing.T) {
+	amount := NewAmountFromInt(CurrencyUSD, 1000)
+	parts, _ := amount.Allocate(1, 2, 4)
+	// 142.857, 285.714 and 571.428, the leftover cent goes to the largest remainder
+	expected := []int64{143, 286, 571}
+	total := int64(0)
+	for i, part := range parts {
+		total += part.GetIntValue()
+		if part.GetIntValue() != expected[i] {
+			t.Errorf("Invalid part %d %d, expected %d", i, part.GetIntValue(), expected[i])
+		}
+	}
+	if total != 1000 {
+		t.Errorf("Parts sum to %d", total)
+	}
+	zeroRatio, _ := amount.Allocate(0, 1)
+	if zeroRatio[0].GetIntValue() != 0 || zeroRatio[1].GetIntValue() != 1000 {
+		t.Errorf("Invalid allocation with zero ratio %d %d", zeroRatio[0].GetIntValue(), zeroRatio[1].GetIntValue())
+	}
+}
+
+func TestAllocateNegative(t *testing.T) {
+	usd := NewUSDFromInt(-5)
+	parts, _ := usd.SplitEvenly(3)
+	expected := []int64{-2, -2, -1}
+	for i, part := range parts {
+		if part.GetIntValue() != expected[i] {
+			t.Errorf("Invalid part %d %d, expected %d", i, part.GetIntValue(), expected[i])
+		}
+	}
+}
+
+func TestSplitEvenlyEther(t *testing.T) {
+	eth, _ := NewEtherFromString("1")
+	parts, err := eth.SplitEvenly(3)
+	if err != nil || len(parts) != 3 {
+		t.Fatalf("Error splitting %v", err)
+	}
+	if parts[0].GetStringValue() != "0.333333333333333334" || parts[2].GetStringValue() != "0.333333333333333333" {
+		t.Errorf("Invalid parts %s %s", parts[0].GetStringValue(), parts[2].GetStringValue())
+	}
+	sum := parts[0].Add(parts[1]).Add(parts[2])
+	if sum.Compare(eth) != 0 {
+		t.Errorf("Parts sum to %s", sum.GetStringValue())
+	}
+}
+
+func TestAllocateErrors(t *testing.T) {
+	btc := NewBitcoinFromInt(100)
+	if _, err := btc.Allocate(); err == nil {
+		t.Error("Expected error allocating with no ratios")
+	}
+	if _, err := btc.Allocate(1, -1); err == nil {
+		t.Error("Expected error allocating with a negative ratio")
+	}
+	if _, err := btc.Allocate(0, 0); err == nil {
+		t.Error("Expected error allocating with ratios summing to zero")
+	}
+	if _, err := btc.SplitEvenly(0); err == nil {
+		t.Error("Expected error splitting into zero parts")
+	}
+}
@@ -0,0 +1,300 @@
+package assets
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+)
+
+const amountSeparator = "."
+
+// Amount fixed point amount of a currency, held as an integer number of the currency's minor units
+type Amount struct {
+	currency Currency
+	value    *big.Int
+}
+
+// NewAmount create an amount from a number of minor units
+func NewAmount(currency Currency, minorUnits *big.Int) Amount {
+	return Amount{currency: currency, value: new(big.Int).Set(minorUnits)}
+}
+
+// NewAmountFromInt create an amount from an int number of minor units
+func NewAmountFromInt(currency Currency, minorUnits int64) Amount {
+	return Amount{currency: currency, value: big.NewInt(minorUnits)}
+}
+
+// ZeroAmount returns an amount with value zero
+func ZeroAmount(currency Currency) Amount {
+	return NewAmountFromInt(currency, 0)
+}
+
+// ParseAmount create an amount from a decimal string, rounding excess fraction digits half up
+func ParseAmount(currency Currency, amountString string) (Amount, error) {
+	return ParseAmountRounded(currency, amountString, RoundHalfUp)
+}
+
+// ParseAmountRounded create an amount from a decimal string, rounding excess fraction digits with the rounding mode
+func ParseAmountRounded(currency Currency, amountString string, mode RoundingMode) (Amount, error) {
+	value, err := parseDecimalString(currency, amountString, mode)
+	if err != nil {
+		return Amount{}, err
+	}
+	return Amount{currency: currency, value: value}, nil
+}
+
+// Currency the currency the amount is denominated in
+func (amount Amount) Currency() Currency {
+	return amount.currency
+}
+
+// MinorUnits the amount as a number of minor units
+func (amount Amount) MinorUnits() *big.Int {
+	return new(big.Int).Set(amount.minorUnits())
+}
+
+// GetIntValue the number of minor units, saturating at the int64 limits, use MinorUnits for the exact value
+func (amount Amount) GetIntValue() int64 {
+	return clampInt64(amount.minorUnits())
+}
+
+func (amount Amount) GetFractionLength() int64 {
+	return amount.currency.Decimals
+}
+
+func (amount Amount) bigIntValue() *big.Int {
+	return amount.MinorUnits()
+}
+
+// GetStringValue the amount with all of the currency's decimals
+func (amount Amount) GetStringValue() string {
+	return formatMinorUnits(amount.minorUnits(), amount.currency.Decimals, amount.currency.Decimals)
+}
+
+// GetPrettyStringValue the amount rounded half up to the currency's display decimals
+func (amount Amount) GetPrettyStringValue() string {
+	return formatMinorUnits(amount.minorUnits(), amount.currency.Decimals, amount.currency.DisplayDecimals)
+}
+
+// Add add an amount of the same currency
+func (amount Amount) Add(amountToAdd Amount) (Amount, error) {
+	if err := amount.checkCurrency("add", amountToAdd); err != nil {
+		return Amount{}, err
+	}
+	return amount.add(amountToAdd), nil
+}
+
+// Subtract subtract an amount of the same currency
+func (amount Amount) Subtract(amountToSubtract Amount) (Amount, error) {
+	if err := amount.checkCurrency("subtract", amountToSubtract); err != nil {
+		return Amount{}, err
+	}
+	return amount.subtract(amountToSubtract), nil
+}
+
+// Compare compare amounts of the same currency ascending
+func (amount Amount) Compare(other Amount) (int, error) {
+	if err := amount.checkCurrency("compare", other); err != nil {
+		return 0, err
+	}
+	return amount.compare(other), nil
+}
+
+// MultiplyRounded multiply by value / 10^fractionLength, rounding fractions of a minor unit with the rounding mode.
+// A negative fractionLength scales the value up, 3 at -1 multiplies by 30
+func (amount Amount) MultiplyRounded(value int64, fractionLength int64, mode RoundingMode) Amount {
+	product := new(big.Int).Mul(amount.minorUnits(), big.NewInt(value))
+	if fractionLength < 0 {
+		return amount.withValue(product.Mul(product, pow10Big(-fractionLength)))
+	}
+	return amount.withValue(quoRound(product, pow10Big(fractionLength), mode))
+}
+
+// GetCostRounded cost of the amount at a price per whole unit, in the price's currency
+func (amount Amount) GetCostRounded(price Amount, mode RoundingMode) Amount {
+	cost := new(big.Int).Mul(price.minorUnits(), amount.minorUnits())
+	return price.withValue(quoRound(cost, pow10Big(amount.currency.Decimals), mode))
+}
+
+// GetUnitCostAtPriceRounded price per whole unit when the amount costs price, in the price's currency.
+// A zero amount has no unit cost and gives zero
+func (amount Amount) GetUnitCostAtPriceRounded(price Amount, mode RoundingMode) Amount {
+	if amount.IsZero() {
+		return ZeroAmount(price.currency)
+	}
+	scaledPrice := new(big.Int).Mul(price.minorUnits(), pow10Big(amount.currency.Decimals))
+	return price.withValue(quoRound(scaledPrice, amount.minorUnits(), mode))
+}
+
+// Abs the amount without its sign
+func (amount Amount) Abs() Amount {
+	return amount.withValue(new(big.Int).Abs(amount.minorUnits()))
+}
+
+// Neg the amount with its sign flipped
+func (amount Amount) Neg() Amount {
+	return amount.withValue(new(big.Int).Neg(amount.minorUnits()))
+}
+
+// Sign -1, 0 or 1 for negative, zero and positive amounts
+func (amount Amount) Sign() int {
+	return amount.minorUnits().Sign()
+}
+
+// IsZero true when the amount is zero
+func (amount Amount) IsZero() bool {
+	return amount.Sign() == 0
+}
+
+// IsNegative true when the amount is less than zero
+func (amount Amount) IsNegative() bool {
+	return amount.Sign() < 0
+}
+
+func (amount Amount) minorUnits() *big.Int {
+	if amount.value == nil {
+		return new(big.Int)
+	}
+	return amount.value
+}
+
+func (amount Amount) withValue(value *big.Int) Amount {
+	return Amount{currency: amount.currency, value: value}
+}
+
+func (amount Amount) add(amountToAdd Amount) Amount {
+	return amount.withValue(new(big.Int).Add(amount.minorUnits(), amountToAdd.minorUnits()))
+}
+
+func (amount Amount) subtract(amountToSubtract Amount) Amount {
+	return amount.withValue(new(big.Int).Sub(amount.minorUnits(), amountToSubtract.minorUnits()))
+}
+
+func (amount Amount) compare(other Amount) int {
+	return amount.minorUnits().Cmp(other.minorUnits())
+}
+
+// currencies must match on code, decimals and symbol, tokens sharing a symbol can differ in decimals
+func (amount Amount) checkCurrency(operation string, other Amount) error {
+	if amount.currency.Code != other.currency.Code {
+		return newCurrencyMismatchError(operation, amount.currency, other.currency)
+	}
+	if amount.currency.Decimals != other.currency.Decimals || amount.currency.Symbol != other.currency.Symbol {
+		return CurrencyError{message: "Currency mismatch in " + operation + " -- [" + amount.currency.Code + "] amounts have different decimals or symbols"}
+	}
+	return nil
+}
+
+// pad the fraction to fractionLength digits, keeping any excess digits so they can be rounded when parsed.
+// A missing whole part is written as 0 and a negative zero loses its sign, so -.5 becomes -0.5 and -0 becomes 0,
+// a string without digits is returned unchanged
+func standardizeAmountString(amountString string, fractionLength int64) string {
+	pieces := strings.Split(amountString, amountSeparator)
+	fractionString := ""
+	if len(pieces) > 1 {
+		fractionString = pieces[1]
+	}
+	wholeString := pieces[0]
+	if strings.TrimPrefix(wholeString, "-") == "" {
+		if fractionString == "" {
+			// no digits to standardize, left for parsing to reject
+			return amountString
+		}
+		wholeString += "0"
+	}
+	if strings.HasPrefix(wholeString, "-") && strings.Trim(wholeString[1:]+fractionString, "0") == "" {
+		wholeString = wholeString[1:]
+	}
+	if fractionString == "" && fractionLength == 0 {
+		return wholeString
+	}
+	var standardizedBuffer bytes.Buffer
+	standardizedBuffer.WriteString(wholeString)
+	standardizedBuffer.WriteString(amountSeparator)
+	standardizedBuffer.WriteString(fractionString)
+	for i := int64(len(fractionString)); i < fractionLength; i++ {
+		standardizedBuffer.WriteString("0")
+	}
+	return standardizedBuffer.String()
+}
+
+func parseDecimalString(currency Currency, amountString string, mode RoundingMode) (*big.Int, error) {
+	if len(amountString) < 1 {
+		return nil, ConversionError{message: "Empty string passed for " + currency.Code + " conversion [" + amountString + "]"}
+	}
+	pieces := strings.Split(amountString, amountSeparator)
+	if strings.TrimPrefix(pieces[0], "-") == "" && (len(pieces) < 2 || pieces[1] == "") {
+		return nil, ConversionError{message: "No digits in " + currency.Code + " conversion [" + amountString + "]"}
+	}
+	negative := strings.HasPrefix(pieces[0], "-")
+	fraction := new(big.Int)
+	if len(pieces) > 1 && pieces[1] != "" {
+		var err error
+		fraction, err = roundFractionString(pieces[1], currency.Decimals, negative, mode)
+		if err != nil {
+			return nil, ConversionError{message: "Error converting fraction " + currency.Code + " [" + pieces[1] + "] for string [" + amountString + "] -- [" + err.Error() + "]"}
+		}
+	}
+	wholeUnits, err := convertWholeUnits(strings.TrimPrefix(pieces[0], "-"), currency.Decimals)
+	if err != nil {
+		return nil, ConversionError{message: "Error converting whole " + currency.Code + " [" + pieces[0] + "] for string [" + amountString + "] -- [" + err.Error() + "]"}
+	}
+	if negative {
+		wholeUnits.Neg(wholeUnits)
+	}
+	return wholeUnits.Add(wholeUnits, fraction), nil
+}
+
+func convertWholeUnits(wholeString string, fractionLength int64) (*big.Int, error) {
+	if wholeString == "" {
+		return new(big.Int), nil
+	}
+	wholeUnits, err := parseUnsignedBigInt(wholeString)
+	if err != nil {
+		return nil, err
+	}
+	return wholeUnits.Mul(wholeUnits, pow10Big(fractionLength)), nil
+}
+
+func parseUnsignedBigInt(digits string) (*big.Int, error) {
+	for _, digit := range digits {
+		if digit < '0' || digit > '9' {
+			return nil, ConversionError{message: "Invalid digit in [" + digits + "]"}
+		}
+	}
+	value, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, ConversionError{message: "Invalid number [" + digits + "]"}
+	}
+	return value, nil
+}
+
+// format minor units with fractionLength decimals as a decimal string with fractionsToPrint digits,
+// rounding half up when printing fewer digits than are held
+func formatMinorUnits(value *big.Int, fractionLength, fractionsToPrint int64) string {
+	if fractionsToPrint < fractionLength {
+		value = quoRound(value, pow10Big(fractionLength-fractionsToPrint), RoundHalfUp)
+		fractionLength = fractionsToPrint
+	}
+	wholeUnits, fractionUnits := new(big.Int).QuoRem(new(big.Int).Abs(value), pow10Big(fractionLength), new(big.Int))
+	var buffer bytes.Buffer
+	if value.Sign() < 0 {
+		buffer.WriteString("-")
+	}
+	buffer.WriteString(wholeUnits.String())
+	if fractionsToPrint == 0 {
+		return buffer.String()
+	}
+	buffer.WriteString(amountSeparator)
+	fractionString := fractionUnits.String()
+	for i := int64(len(fractionString)); i < fractionLength; i++ {
+		buffer.WriteString("0")
+	}
+	if fractionLength > 0 {
+		buffer.WriteString(fractionString)
+	}
+	for i := fractionLength; i < fractionsToPrint; i++ {
+		buffer.WriteString("0")
+	}
+	return buffer.String()
+}
@@ -0,0 +1,91 @@
+package assets
+
+import "testing"
+
+func TestRegisteredCurrencyAmount(t *testing.T) {
+	litecoin := Currency{Code: "LTC", Symbol: "Ł", Decimals: 8, DisplayDecimals: 4}
+	if err := RegisterCurrency(litecoin); err != nil {
+		t.Error(err)
+		return
+	}
+	registered, ok := LookupCurrency("LTC")
+	if !ok || registered != litecoin {
+		t.Errorf("Expected LTC to be registered but got %v", registered)
+	}
+	ltc, err := ParseAmount(registered, "12.34567891")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if ltc.GetStringValue() != "12.34567891" {
+		t.Errorf("Invalid string value %s", ltc.GetStringValue())
+	}
+	if ltc.GetPrettyStringValue() != "12.3457" {
+		t.Errorf("Invalid pretty string value %s", ltc.GetPrettyStringValue())
+	}
+	doubled, err := ltc.Add(ltc)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if doubled.GetStringValue() != "24.69135782" {
+		t.Errorf("Invalid sum %s", doubled.GetStringValue())
+	}
+}
+
+func TestRegisterCurrencyConflict(t *testing.T) {
+	if err := RegisterCurrency(Currency{Code: "USD", Symbol: "$", Decimals: 4, DisplayDecimals: 2}); err == nil {
+		t.Error("Expected error re-registering USD with different decimals")
+	}
+	if err := RegisterCurrency(CurrencyUSD); err != nil {
+		t.Errorf("Expected re-registering the same descriptor to succeed %v", err)
+	}
+	if err := RegisterCurrency(Currency{Symbol: "?"}); err == nil {
+		t.Error("Expected error registering currency without a code")
+	}
+}
+
+func TestAmountCurrencyMismatch(t *testing.T) {
+	btc := NewAmountFromInt(CurrencyBTC, 100)
+	usd := NewAmountFromInt(CurrencyUSD, 100)
+	if _, err := btc.Add(usd); err == nil {
+		t.Error("Expected error adding USD to BTC")
+	} else if _, ok := err.(CurrencyError); !ok {
+		t.Errorf("Expected CurrencyError but got %v", err)
+	}
+	if _, err := btc.Compare(usd); err == nil {
+		t.Error("Expected error comparing USD to BTC")
+	}
+	if _, err := NewBitcoinFromAmount(usd); err == nil {
+		t.Error("Expected error creating bitcoin from USD amount")
+	}
+	usdc6 := TokenDescriptor{Address: "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", Symbol: "USDC", Decimals: 6}.Currency()
+	usdc18 := TokenDescriptor{Address: "0x1111111111111111111111111111111111111111", Symbol: "USDC", Decimals: 18}.Currency()
+	if sum, err := NewAmountFromInt(usdc6, 1).Add(NewAmountFromInt(usdc18, 1)); err == nil {
+		t.Errorf("Expected error adding tokens with the same symbol and different decimals but got %v", sum)
+	}
+	if _, err := NewAmountFromInt(usdc6, 1).Compare(NewAmountFromInt(usdc18, 1)); err == nil {
+		t.Error("Expected error comparing tokens with the same symbol and different decimals")
+	}
+}
+
+func TestZeroDecimalCurrency(t *testing.T) {
+	yen := Currency{Code: "TESTJPY", Symbol: "¥", Decimals: 0, DisplayDecimals: 0}
+	amount, err := ParseAmount(yen, "1500.5")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if amount.GetStringValue() != "1501" {
+		t.Errorf("Invalid zero decimal amount %s", amount.GetStringValue())
+	}
+}
+
+func TestWrappersShareAmount(t *testing.T) {
+	btc, _ := NewBitcoinFromString("0.5")
+	price, _ := NewUSDFromString("60000.00")
+	cost := btc.Amount().GetCostRounded(price.Amount(), RoundTruncate)
+	if cost.Currency() != CurrencyUSD || cost.GetStringValue() != btc.GetCost(price).GetStringValue() {
+		t.Errorf("Invalid amount cost %s", cost.GetStringValue())
+	}
+}
@@ -1,32 +1,83 @@
 package assets
 
-import "math"
+import "math/big"
 
-// DivideByAsset divides an asset by another, outputing in the specified fraction decimal length
+// DivideByAsset divides an asset by another, outputing in the specified fraction decimal length,
+// use DivideByQuantity when both are the same type. Panics if the divisor is zero, which used to
+// give a meaningless result instead
 func DivideByAsset(dividend Asset, divisor Asset, outputFractionDecimalLength int64) Asset {
-  ratio := float64(dividend.GetIntValue()) / float64(divisor.GetIntValue())
-  multiplierPower := (divisor.GetFractionLength() - dividend.GetFractionLength()) + outputFractionDecimalLength
-  multiplier := math.Pow10(int(multiplierPower))
-  resultFloat := math.Round(ratio * multiplier)
-  resultInt := int64(resultFloat)
-  return assetStruct{value: resultInt, fractionLength: outputFractionDecimalLength}
+	return DivideByAssetRounded(dividend, divisor, outputFractionDecimalLength, RoundHalfUp)
 }
 
-// Divide divids an assent by an int, outputs an asset with the specified fraction decimal length
+// DivideByAssetRounded divides an asset by another, rounding to the specified fraction decimal length with the rounding mode.
+// Panics if the divisor is zero
+func DivideByAssetRounded(dividend Asset, divisor Asset, outputFractionDecimalLength int64, mode RoundingMode) Asset {
+	quotient, _ := DivideByAssetWithRemainder(dividend, divisor, outputFractionDecimalLength, mode)
+	return quotient
+}
+
+// DivideByAssetWithRemainder divides an asset by another, also returning the remainder in the dividend's units
+// such that dividend = quotient * divisor + remainder. Panics if the divisor is zero
+func DivideByAssetWithRemainder(dividend Asset, divisor Asset, outputFractionDecimalLength int64, mode RoundingMode) (Asset, Asset) {
+	return divideScaled(dividend, assetBigValue(divisor), divisor.GetFractionLength(), outputFractionDecimalLength, mode)
+}
+
+// Divide divids an assent by an int, outputs an asset with the specified fraction decimal length,
+// use DivideQuantity to keep the dividend's type. Panics if the divisor is zero, which used to
+// give a meaningless result instead
 func Divide(dividend Asset, divisor, outputFractionDecimalLength int64) Asset {
-  ratio := float64(dividend.GetIntValue()) / float64(divisor)
-  multiplierPower := outputFractionDecimalLength - dividend.GetFractionLength()
-  multiplier := math.Pow10(int(multiplierPower))
-  resultFloat := math.Round(ratio * multiplier)
-  resultInt := int64(resultFloat)
-  return assetStruct{value: resultInt, fractionLength: outputFractionDecimalLength}
+	return DivideRounded(dividend, divisor, outputFractionDecimalLength, RoundHalfUp)
 }
 
+// DivideRounded divides an asset by an int, rounding to the specified fraction decimal length with the rounding mode.
+// Panics if the divisor is zero
+func DivideRounded(dividend Asset, divisor, outputFractionDecimalLength int64, mode RoundingMode) Asset {
+	quotient, _ := DivideWithRemainder(dividend, divisor, outputFractionDecimalLength, mode)
+	return quotient
+}
+
+// DivideWithRemainder divides an asset by an int, also returning the remainder
+// such that dividend = quotient * divisor + remainder. Panics if the divisor is zero
+func DivideWithRemainder(dividend Asset, divisor, outputFractionDecimalLength int64, mode RoundingMode) (Asset, Asset) {
+	return divideScaled(dividend, big.NewInt(divisor), 0, outputFractionDecimalLength, mode)
+}
 
+// exact division of dividend by divisor / 10^divisorLength, all values are brought to a common
+// scale so the quotient is a single rounded integer division and the remainder is exact
+func divideScaled(dividend Asset, divisor *big.Int, divisorLength, outputLength int64, mode RoundingMode) (Asset, Asset) {
+	dividendLength := dividend.GetFractionLength()
+	remainderLength := dividendLength
+	if divisorLength+outputLength > remainderLength {
+		remainderLength = divisorLength + outputLength
+	}
+	numerator := new(big.Int).Mul(assetBigValue(dividend), pow10Big(remainderLength-dividendLength))
+	denominator := new(big.Int).Mul(divisor, pow10Big(remainderLength-divisorLength-outputLength))
+	quotient := quoRound(numerator, denominator, mode)
+	remainder := new(big.Int).Sub(numerator, new(big.Int).Mul(quotient, denominator))
+	return assetStruct{value: quotient, fractionLength: outputLength}, assetStruct{value: remainder, fractionLength: remainderLength}
+}
+
+// assets backed by big integers expose their full value, everything else fits in GetIntValue
+type bigIntValued interface {
+	bigIntValue() *big.Int
+}
+
+func assetBigValue(asset Asset) *big.Int {
+	if bigAsset, ok := asset.(bigIntValued); ok {
+		return bigAsset.bigIntValue()
+	}
+	return big.NewInt(asset.GetIntValue())
+}
+
+// GetIntValue saturating at the int64 limits when the value does not fit
 func (asset assetStruct) GetIntValue() int64 {
-  return asset.value
+	return clampInt64(asset.value)
 }
 
 func (asset assetStruct) GetFractionLength() int64 {
-  return asset.fractionLength
+	return asset.fractionLength
+}
+
+func (asset assetStruct) bigIntValue() *big.Int {
+	return new(big.Int).Set(asset.value)
 }
@@ -0,0 +1,90 @@
+package assets
+
+import "testing"
+
+func TestDivideByAsset(t *testing.T) {
+	btc, _ := NewBitcoinFromString("1.5")
+	usd, _ := NewUSDFromString("3.00")
+	ratio := DivideByAsset(btc, usd, 4)
+	if ratio.GetIntValue() != 5000 || ratio.GetFractionLength() != 4 {
+		t.Errorf("Invalid ratio %d at fraction length %d", ratio.GetIntValue(), ratio.GetFractionLength())
+	}
+}
+
+func TestDivideByAssetIsExact(t *testing.T) {
+	dividend := NewBitcoinFromInt(9007199254740993)
+	divisor := NewBitcoinFromInt(100000000)
+	quotient, remainder := DivideByAssetWithRemainder(dividend, divisor, 8, RoundHalfUp)
+	if quotient.GetIntValue() != 9007199254740993 {
+		t.Errorf("Invalid quotient %d", quotient.GetIntValue())
+	}
+	if remainder.GetIntValue() != 0 {
+		t.Errorf("Invalid remainder %d", remainder.GetIntValue())
+	}
+	eth, _ := NewEtherFromString("100.000000000000000003")
+	ethQuotient, ethRemainder := DivideByAssetWithRemainder(eth, NewEtherFromInt(2), 0, RoundTruncate)
+	if assetBigValue(ethQuotient).String() != "50000000000000000001" || ethRemainder.GetIntValue() != 1 {
+		t.Errorf("Invalid ether division %s rem %d", assetBigValue(ethQuotient).String(), ethRemainder.GetIntValue())
+	}
+}
+
+func TestDivideByZeroPanics(t *testing.T) {
+	usd, _ := NewUSDFromString("10.00")
+	for name, divide := range map[string]func(){
+		"DivideByAsset": func() { DivideByAsset(usd, NewUSDFromInt(0), 2) },
+		"Divide":        func() { Divide(usd, 0, 2) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected %s by zero to panic", name)
+				}
+			}()
+			divide()
+		}()
+	}
+}
+
+func TestDivideWithRemainder(t *testing.T) {
+	usd, _ := NewUSDFromString("10.00")
+	quotient, remainder := DivideWithRemainder(usd, 3, 2, RoundHalfUp)
+	if quotient.GetIntValue() != 333 || quotient.GetFractionLength() != 2 {
+		t.Errorf("Invalid quotient %d", quotient.GetIntValue())
+	}
+	if remainder.GetIntValue() != 1 || remainder.GetFractionLength() != 2 {
+		t.Errorf("Invalid remainder %d at fraction length %d", remainder.GetIntValue(), remainder.GetFractionLength())
+	}
+	ceiling := DivideRounded(usd, 3, 2, RoundCeiling)
+	if ceiling.GetIntValue() != 334 {
+		t.Errorf("Invalid ceiling quotient %d", ceiling.GetIntValue())
+	}
+	negative, _ := NewUSDFromString("-10.00")
+	floor := DivideRounded(negative, 3, 2, RoundFloor)
+	if floor.GetIntValue() != -334 {
+		t.Errorf("Invalid floor quotient %d", floor.GetIntValue())
+	}
+}
+
+func TestRoundingModes(t *testing.T) {
+	cases := []struct {
+		value    int64
+		mode     RoundingMode
+		expected int64
+	}{
+		{25, RoundHalfUp, 3},
+		{-25, RoundHalfUp, -3},
+		{25, RoundHalfEven, 2},
+		{35, RoundHalfEven, 4},
+		{25, RoundHalfDown, 2},
+		{26, RoundHalfDown, 3},
+		{-21, RoundFloor, -3},
+		{21, RoundCeiling, 3},
+		{-29, RoundTruncate, -2},
+	}
+	for _, c := range cases {
+		actual := DivideRounded(NewUSDFromInt(c.value), 10, 2, c.mode)
+		if actual.GetIntValue() != c.expected {
+			t.Errorf("Rounding %d with mode %d expected %d but got %d", c.value, c.mode, c.expected, actual.GetIntValue())
+		}
+	}
+}
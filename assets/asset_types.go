@@ -1,16 +1,34 @@
 package assets
 
+import "math/big"
+
 // Bitcoin bitcoin asset type
 type Bitcoin interface {
 	GetStringValue() string
 	GetIntValue() int64
 	Add(Bitcoin) Bitcoin
+	AddChecked(Bitcoin) (Bitcoin, error)
 	Subtract(Bitcoin) Bitcoin
+	SubtractChecked(Bitcoin) (Bitcoin, error)
 	GetCost(USD) USD
+	GetCostChecked(USD) (USD, error)
+	GetCostRounded(USD, RoundingMode) USD
 	Multiply(value int64, fractionDigits int64) Bitcoin
+	MultiplyChecked(value int64, fractionDigits int64) (Bitcoin, error)
+	MultiplyRounded(value int64, fractionDigits int64, mode RoundingMode) Bitcoin
 	GetFractionLength() int64
 	Compare(Bitcoin) int
+	Abs() Bitcoin
+	Neg() Bitcoin
+	Sign() int
+	IsZero() bool
+	IsNegative() bool
 	GetUnitCostAtPrice(USD) USD
+	GetUnitCostAtPriceRounded(USD, RoundingMode) USD
+	Allocate(ratios ...int64) ([]Bitcoin, error)
+	SplitEvenly(n int) ([]Bitcoin, error)
+	Amount() Amount
+	fromAmount(Amount) Bitcoin
 }
 
 // Asset an asset
@@ -20,13 +38,13 @@ type Asset interface {
 }
 
 type assetStruct struct {
-	value int64
+	value          *big.Int
 	fractionLength int64
 }
 
 type bitcoinStruct struct {
 	stringValue string
-	intValue    int64
+	amount      Amount
 }
 
 // USD asset type
@@ -35,15 +53,53 @@ type USD interface {
 	GetPrettyStringValue() string
 	GetIntValue() int64
 	Add(USD) USD
+	AddChecked(USD) (USD, error)
 	Subtract(USD) USD
+	SubtractChecked(USD) (USD, error)
 	Multiply(value int64, fractionDigits int64) USD
+	MultiplyChecked(value int64, fractionDigits int64) (USD, error)
+	MultiplyRounded(value int64, fractionDigits int64, mode RoundingMode) USD
 	Compare(USD) int
+	Abs() USD
+	Neg() USD
+	Sign() int
+	IsZero() bool
+	IsNegative() bool
 	GetFractionLength() int64
+	Allocate(ratios ...int64) ([]USD, error)
+	SplitEvenly(n int) ([]USD, error)
+	Amount() Amount
+	fromAmount(Amount) USD
 }
 
 type usdStruct struct {
 	stringValue string
-	intValue    int64
+	amount      Amount
+}
+
+// Fiat government currency asset type following ISO 4217 minor units, fiat of different currencies cannot be combined
+type Fiat interface {
+	GetStringValue() string
+	GetPrettyStringValue() string
+	GetIntValue() int64
+	Currency() Currency
+	Add(Fiat) (Fiat, error)
+	Subtract(Fiat) (Fiat, error)
+	Multiply(value int64, fractionDigits int64) Fiat
+	MultiplyRounded(value int64, fractionDigits int64, mode RoundingMode) Fiat
+	Compare(Fiat) (int, error)
+	Abs() Fiat
+	Neg() Fiat
+	Sign() int
+	IsZero() bool
+	IsNegative() bool
+	GetFractionLength() int64
+	Amount() Amount
+}
+
+type fiatStruct struct {
+	stringValue string
+	amount      Amount
 }
 
 // Ether  asset type
@@ -53,14 +109,52 @@ type Ether interface {
 	Add(Ether) Ether
 	Subtract(Ether) Ether
 	GetCost(USD) USD
+	GetCostChecked(USD) (USD, error)
+	GetCostRounded(USD, RoundingMode) USD
 	Multiply(value int64, percentMultiplier int64) Ether
+	MultiplyRounded(value int64, percentMultiplier int64, mode RoundingMode) Ether
 	GetFractionLength() int64
 	Compare(Ether) int
+	Abs() Ether
+	Neg() Ether
+	Sign() int
+	IsZero() bool
+	IsNegative() bool
+	Wei() *big.Int
+	Gwei() *big.Int
+	Allocate(ratios ...int64) ([]Ether, error)
+	SplitEvenly(n int) ([]Ether, error)
+	Amount() Amount
+	fromAmount(Amount) Ether
 }
 
 type etherStruct struct {
 	stringValue string
-	intValue    int64
+	amount      Amount
+}
+
+// Token erc-20 style token asset type, tokens of different contracts cannot be combined
+type Token interface {
+	GetStringValue() string
+	GetIntValue() int64
+	Add(Token) (Token, error)
+	Subtract(Token) (Token, error)
+	GetCost(USD) USD
+	GetCostChecked(USD) (USD, error)
+	GetCostRounded(USD, RoundingMode) USD
+	Multiply(value int64, percentMultiplier int64) Token
+	MultiplyRounded(value int64, percentMultiplier int64, mode RoundingMode) Token
+	GetFractionLength() int64
+	Compare(Token) (int, error)
+	BaseUnits() *big.Int
+	Descriptor() TokenDescriptor
+	Amount() Amount
+}
+
+type tokenStruct struct {
+	descriptor  TokenDescriptor
+	stringValue string
+	amount      Amount
 }
 
 // ConversionError error converting
@@ -0,0 +1,343 @@
+package assets
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"math/big"
+	"strconv"
+)
+
+// binary format version written by MarshalBinary
+const binaryVersion = 1
+
+// MarshalBinary implements encoding.BinaryMarshaler, writing the currency and minor units
+func (amount Amount) MarshalBinary() ([]byte, error) {
+	buffer := []byte{binaryVersion}
+	buffer = appendCurrency(buffer, amount.currency)
+	buffer, isBig := appendValueFlag(buffer, amount.minorUnits())
+	return appendMinorUnits(buffer, amount.minorUnits(), isBig), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, reading data written by MarshalBinary
+func (amount *Amount) UnmarshalBinary(data []byte) error {
+	reader := &binaryReader{data: data}
+	if version := reader.readByte(); reader.err == nil && version != binaryVersion {
+		return ConversionError{message: "Unsupported amount binary version [" + strconv.Itoa(int(version)) + "]"}
+	}
+	currency := reader.readCurrency()
+	isBig := reader.readUvarint() == 1
+	value := reader.readMinorUnits(isBig)
+	if reader.err != nil {
+		return reader.err
+	}
+	if reader.position != len(data) {
+		return ConversionError{message: "Trailing bytes after amount binary"}
+	}
+	*amount = Amount{currency: currency, value: value}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (bitcoin bitcoinStruct) MarshalBinary() ([]byte, error) {
+	return bitcoin.amount.MarshalBinary()
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (ether etherStruct) MarshalBinary() ([]byte, error) {
+	return ether.amount.MarshalBinary()
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (usd usdStruct) MarshalBinary() ([]byte, error) {
+	return usd.amount.MarshalBinary()
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (fiat fiatStruct) MarshalBinary() ([]byte, error) {
+	return fiat.amount.MarshalBinary()
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, an error when there is no Bitcoin
+func (value BitcoinValue) MarshalBinary() ([]byte, error) {
+	if value.Bitcoin == nil {
+		return nil, ConversionError{message: "Cannot marshal a BitcoinValue without a Bitcoin"}
+	}
+	return value.Bitcoin.Amount().MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (value *BitcoinValue) UnmarshalBinary(data []byte) error {
+	var amount Amount
+	if err := amount.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	bitcoin, err := NewBitcoinFromAmount(amount)
+	if err != nil {
+		return err
+	}
+	value.Bitcoin = bitcoin
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, an error when there is no Ether
+func (value EtherValue) MarshalBinary() ([]byte, error) {
+	if value.Ether == nil {
+		return nil, ConversionError{message: "Cannot marshal an EtherValue without an Ether"}
+	}
+	return value.Ether.Amount().MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (value *EtherValue) UnmarshalBinary(data []byte) error {
+	var amount Amount
+	if err := amount.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	ether, err := NewEtherFromAmount(amount)
+	if err != nil {
+		return err
+	}
+	value.Ether = ether
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, an error when there is no USD
+func (value USDValue) MarshalBinary() ([]byte, error) {
+	if value.USD == nil {
+		return nil, ConversionError{message: "Cannot marshal a USDValue without a USD"}
+	}
+	return value.USD.Amount().MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (value *USDValue) UnmarshalBinary(data []byte) error {
+	var amount Amount
+	if err := amount.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	usd, err := NewUSDFromAmount(amount)
+	if err != nil {
+		return err
+	}
+	value.USD = usd
+	return nil
+}
+
+// StreamEncoder writes a sequence of amounts. Each currency is described once, the first time
+// it is written, and later amounts refer to it by index, so a record is usually a one byte
+// header followed by the minor units as a zig-zag varint
+type StreamEncoder struct {
+	writer io.Writer
+	// keyed by the whole descriptor, currencies sharing a code are described separately
+	currencies map[Currency]uint64
+	buffer     []byte
+}
+
+// NewStreamEncoder create an encoder writing to writer, wrap it in a bufio.Writer for small writes
+func NewStreamEncoder(writer io.Writer) *StreamEncoder {
+	return &StreamEncoder{writer: writer, currencies: map[Currency]uint64{}}
+}
+
+// Encode write an amount
+func (encoder *StreamEncoder) Encode(amount Amount) error {
+	buffer := encoder.buffer[:0]
+	index, known := encoder.currencies[amount.currency]
+	if !known {
+		index = uint64(len(encoder.currencies))
+	}
+	isBig := !amount.minorUnits().IsInt64()
+	header := index << 1
+	if isBig {
+		header |= 1
+	}
+	buffer = binary.AppendUvarint(buffer, header)
+	if !known {
+		buffer = appendCurrency(buffer, amount.currency)
+	}
+	buffer = appendMinorUnits(buffer, amount.minorUnits(), isBig)
+	encoder.buffer = buffer
+	if _, err := encoder.writer.Write(buffer); err != nil {
+		return err
+	}
+	if !known {
+		encoder.currencies[amount.currency] = index
+	}
+	return nil
+}
+
+// StreamDecoder reads amounts written by a StreamEncoder
+type StreamDecoder struct {
+	reader     io.ByteReader
+	currencies []Currency
+}
+
+// NewStreamDecoder create a decoder reading from reader
+func NewStreamDecoder(reader io.Reader) *StreamDecoder {
+	byteReader, ok := reader.(io.ByteReader)
+	if !ok {
+		byteReader = bufio.NewReader(reader)
+	}
+	return &StreamDecoder{reader: byteReader}
+}
+
+// Decode read the next amount, returning io.EOF when the stream ends cleanly
+func (decoder *StreamDecoder) Decode() (Amount, error) {
+	header, err := binary.ReadUvarint(decoder.reader)
+	if err != nil {
+		return Amount{}, err
+	}
+	reader := &binaryReader{byteReader: decoder.reader}
+	index := header >> 1
+	switch {
+	case index == uint64(len(decoder.currencies)):
+		currency := reader.readCurrency()
+		if reader.err != nil {
+			return Amount{}, reader.err
+		}
+		decoder.currencies = append(decoder.currencies, currency)
+	case index > uint64(len(decoder.currencies)):
+		return Amount{}, ConversionError{message: "Unknown currency index [" + strconv.FormatUint(index, 10) + "] in amount stream"}
+	}
+	value := reader.readMinorUnits(header&1 == 1)
+	if reader.err != nil {
+		return Amount{}, reader.err
+	}
+	return Amount{currency: decoder.currencies[index], value: value}, nil
+}
+
+func appendCurrency(buffer []byte, currency Currency) []byte {
+	buffer = appendString(buffer, currency.Code)
+	buffer = appendString(buffer, currency.Symbol)
+	buffer = binary.AppendUvarint(buffer, uint64(currency.Decimals))
+	return binary.AppendUvarint(buffer, uint64(currency.DisplayDecimals))
+}
+
+func appendString(buffer []byte, value string) []byte {
+	buffer = binary.AppendUvarint(buffer, uint64(len(value)))
+	return append(buffer, value...)
+}
+
+func appendValueFlag(buffer []byte, value *big.Int) ([]byte, bool) {
+	if value.IsInt64() {
+		return binary.AppendUvarint(buffer, 0), false
+	}
+	return binary.AppendUvarint(buffer, 1), true
+}
+
+// int64 values as a zig-zag varint, larger values as a length and sign followed by the magnitude
+func appendMinorUnits(buffer []byte, value *big.Int, isBig bool) []byte {
+	if !isBig {
+		return binary.AppendVarint(buffer, value.Int64())
+	}
+	magnitude := value.Bytes()
+	lengthAndSign := uint64(len(magnitude)) << 1
+	if value.Sign() < 0 {
+		lengthAndSign |= 1
+	}
+	buffer = binary.AppendUvarint(buffer, lengthAndSign)
+	return append(buffer, magnitude...)
+}
+
+// binaryReader reads from a byte slice or an io.ByteReader, keeping the first error
+type binaryReader struct {
+	data       []byte
+	position   int
+	byteReader io.ByteReader
+	err        error
+}
+
+func (reader *binaryReader) ReadByte() (byte, error) {
+	if reader.byteReader != nil {
+		return reader.byteReader.ReadByte()
+	}
+	if reader.position >= len(reader.data) {
+		return 0, io.ErrUnexpectedEOF
+	}
+	reader.position++
+	return reader.data[reader.position-1], nil
+}
+
+func (reader *binaryReader) readByte() byte {
+	if reader.err != nil {
+		return 0
+	}
+	value, err := reader.ReadByte()
+	reader.setErr(err)
+	return value
+}
+
+func (reader *binaryReader) readUvarint() uint64 {
+	if reader.err != nil {
+		return 0
+	}
+	value, err := binary.ReadUvarint(reader)
+	reader.setErr(err)
+	return value
+}
+
+func (reader *binaryReader) readVarint() int64 {
+	if reader.err != nil {
+		return 0
+	}
+	value, err := binary.ReadVarint(reader)
+	reader.setErr(err)
+	return value
+}
+
+func (reader *binaryReader) readBytes(length uint64) []byte {
+	if reader.err != nil {
+		return nil
+	}
+	if reader.byteReader == nil && length > uint64(len(reader.data)-reader.position) {
+		reader.setErr(io.ErrUnexpectedEOF)
+		return nil
+	}
+	var value []byte
+	for i := uint64(0); i < length && reader.err == nil; i++ {
+		value = append(value, reader.readByte())
+	}
+	return value
+}
+
+// a currency with a registered code must match the registered descriptor
+func (reader *binaryReader) readCurrency() Currency {
+	code := string(reader.readBytes(reader.readUvarint()))
+	symbol := string(reader.readBytes(reader.readUvarint()))
+	decimals := reader.readUvarint()
+	displayDecimals := reader.readUvarint()
+	if reader.err != nil {
+		return Currency{}
+	}
+	if decimals > maxCurrencyDecimals || displayDecimals > maxCurrencyDecimals {
+		reader.setErr(ConversionError{message: "Invalid decimals for currency [" + code + "] in amount binary"})
+		return Currency{}
+	}
+	currency := Currency{Code: code, Symbol: symbol, Decimals: int64(decimals), DisplayDecimals: int64(displayDecimals)}
+	if registered, ok := LookupCurrency(code); ok {
+		reader.setErr(checkCurrencyDescriptor("amount binary", registered, currency))
+	}
+	return currency
+}
+
+func (reader *binaryReader) readMinorUnits(isBig bool) *big.Int {
+	if !isBig {
+		return big.NewInt(reader.readVarint())
+	}
+	lengthAndSign := reader.readUvarint()
+	value := new(big.Int).SetBytes(reader.readBytes(lengthAndSign >> 1))
+	if lengthAndSign&1 == 1 {
+		value.Neg(value)
+	}
+	return value
+}
+
+// a stream ending part way through a record is unexpected
+func (reader *binaryReader) setErr(err error) {
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if reader.err == nil {
+		reader.err = err
+	}
+}
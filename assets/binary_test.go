@@ -0,0 +1,218 @@
+package assets
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math/big"
+	"testing"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	btc, _ := NewBitcoinFromString("-1.23456789")
+	data, err := btc.(bitcoinStruct).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decodedBtc BitcoinValue
+	if err := decodedBtc.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if decodedBtc.Compare(btc) != 0 {
+		t.Errorf("Invalid decoded bitcoin %v", decodedBtc.Bitcoin)
+	}
+	wei, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	eth := NewEtherFromWei(wei)
+	data, _ = EtherValue{Ether: eth}.MarshalBinary()
+	var decodedEth EtherValue
+	if err := decodedEth.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if decodedEth.Compare(eth) != 0 {
+		t.Errorf("Invalid decoded ether %v", decodedEth.Ether)
+	}
+	var decodedUsd USDValue
+	if err := decodedUsd.UnmarshalBinary(data); err == nil {
+		t.Error("Expected error decoding ether as usd")
+	}
+	if err := decodedBtc.UnmarshalBinary(data[:len(data)-1]); err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected unexpected EOF decoding truncated data but got %v", err)
+	}
+}
+
+func TestBinaryInvalidInput(t *testing.T) {
+	if _, err := (BitcoinValue{}).MarshalBinary(); err == nil {
+		t.Error("Expected error marshaling an empty BitcoinValue")
+	}
+	if _, err := (EtherValue{}).MarshalBinary(); err == nil {
+		t.Error("Expected error marshaling an empty EtherValue")
+	}
+	if _, err := (USDValue{}).MarshalBinary(); err == nil {
+		t.Error("Expected error marshaling an empty USDValue")
+	}
+	forged := Currency{Code: "BTC", Symbol: "₿", Decimals: 2, DisplayDecimals: 2}
+	data, _ := NewAmountFromInt(forged, 150).MarshalBinary()
+	var amount Amount
+	if err := amount.UnmarshalBinary(data); err == nil {
+		t.Errorf("Expected error decoding a forged bitcoin descriptor but got %v", amount)
+	}
+	var decodedBtc BitcoinValue
+	if err := decodedBtc.UnmarshalBinary(data); err == nil {
+		t.Errorf("Expected error decoding a forged bitcoin but got %v", decodedBtc.Bitcoin)
+	}
+	if _, err := NewBitcoinFromAmount(NewAmountFromInt(forged, 150)); err == nil {
+		t.Error("Expected error creating bitcoin from a forged descriptor")
+	}
+	custom := Currency{Code: "XTS", Symbol: "T", Decimals: 4, DisplayDecimals: 2}
+	data, _ = NewAmountFromInt(custom, 12345).MarshalBinary()
+	if err := amount.UnmarshalBinary(data); err != nil || amount.Currency() != custom {
+		t.Errorf("Expected unregistered currency to decode as is but got %v %v", amount.Currency(), err)
+	}
+	huge := append([]byte{binaryVersion}, appendString(appendString(nil, "XTS"), "T")...)
+	huge = append(huge, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f, 0, 0, 1)
+	if err := amount.UnmarshalBinary(huge); err == nil {
+		t.Error("Expected error decoding unbounded decimals")
+	}
+}
+
+func TestStreamRoundTrip(t *testing.T) {
+	btc, _ := NewBitcoinFromString("0.015")
+	usd, _ := NewUSDFromString("60123.45")
+	wei, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	amounts := []Amount{btc.Amount(), usd.Amount(), NewEtherFromWei(wei).Amount(), NewUSDFromInt(-1).Amount(), btc.Amount()}
+	var buffer bytes.Buffer
+	encoder := NewStreamEncoder(&buffer)
+	for _, amount := range amounts {
+		if err := encoder.Encode(amount); err != nil {
+			t.Fatal(err)
+		}
+	}
+	decoder := NewStreamDecoder(&buffer)
+	for _, expected := range amounts {
+		actual, err := decoder.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual.Currency() != expected.Currency() || actual.compare(expected) != 0 {
+			t.Errorf("Expected %v %s but got %v %s", expected, expected.Currency().Code, actual, actual.Currency().Code)
+		}
+	}
+	if _, err := decoder.Decode(); err != io.EOF {
+		t.Errorf("Expected EOF but got %v", err)
+	}
+}
+
+type jsonTick struct {
+	Currency string `json:"currency"`
+	Amount   string `json:"amount"`
+}
+
+func testTicks() []Amount {
+	ticks := make([]Amount, 0, 1000)
+	for i := int64(0); i < 500; i++ {
+		ticks = append(ticks, NewUSDFromInt(6000000+i*7).Amount(), NewBitcoinFromInt(1500000+i*13).Amount())
+	}
+	return ticks
+}
+
+func TestStreamCurrenciesSharingCode(t *testing.T) {
+	usdc6 := TokenDescriptor{Address: "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", Symbol: "USDC", Decimals: 6}.Currency()
+	usdc18 := TokenDescriptor{Address: "0x1111111111111111111111111111111111111111", Symbol: "USDC", Decimals: 18}.Currency()
+	one18, _ := ParseAmount(usdc18, "1")
+	amounts := []Amount{NewAmountFromInt(usdc6, 1000000), one18, NewAmountFromInt(usdc6, 5)}
+	var buffer bytes.Buffer
+	encoder := NewStreamEncoder(&buffer)
+	for _, amount := range amounts {
+		if err := encoder.Encode(amount); err != nil {
+			t.Fatal(err)
+		}
+	}
+	decoder := NewStreamDecoder(&buffer)
+	for _, expected := range amounts {
+		actual, err := decoder.Decode()
+		if err != nil || actual.Currency() != expected.Currency() || actual.compare(expected) != 0 {
+			t.Errorf("Expected %s with %d decimals but got %s with %d decimals %v", expected.GetStringValue(), expected.Currency().Decimals, actual.GetStringValue(), actual.Currency().Decimals, err)
+		}
+	}
+}
+
+func TestStreamSmallerThanJSON(t *testing.T) {
+	ticks := testTicks()
+	var buffer bytes.Buffer
+	encoder := NewStreamEncoder(&buffer)
+	for _, tick := range ticks {
+		encoder.Encode(tick)
+	}
+	var jsonData []byte
+	for _, tick := range ticks {
+		record, _ := json.Marshal(jsonTick{Currency: tick.Currency().Code, Amount: tick.GetStringValue()})
+		jsonData = append(jsonData, record...)
+	}
+	if buffer.Len()*3 > len(jsonData) {
+		t.Errorf("Expected stream of %d bytes to be a third of the JSON size %d", buffer.Len(), len(jsonData))
+	}
+}
+
+func BenchmarkStreamEncode(b *testing.B) {
+	ticks := testTicks()
+	var buffer bytes.Buffer
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buffer.Reset()
+		encoder := NewStreamEncoder(&buffer)
+		for _, tick := range ticks {
+			encoder.Encode(tick)
+		}
+	}
+	b.SetBytes(int64(buffer.Len()))
+}
+
+func BenchmarkJSONEncode(b *testing.B) {
+	ticks := testTicks()
+	var buffer bytes.Buffer
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buffer.Reset()
+		encoder := json.NewEncoder(&buffer)
+		for _, tick := range ticks {
+			encoder.Encode(jsonTick{Currency: tick.Currency().Code, Amount: tick.GetStringValue()})
+		}
+	}
+	b.SetBytes(int64(buffer.Len()))
+}
+
+func BenchmarkStreamDecode(b *testing.B) {
+	var buffer bytes.Buffer
+	encoder := NewStreamEncoder(&buffer)
+	for _, tick := range testTicks() {
+		encoder.Encode(tick)
+	}
+	data := buffer.Bytes()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		decoder := NewStreamDecoder(bytes.NewReader(data))
+		for _, err := decoder.Decode(); err == nil; _, err = decoder.Decode() {
+		}
+	}
+}
+
+func BenchmarkJSONDecode(b *testing.B) {
+	var buffer bytes.Buffer
+	jsonEncoder := json.NewEncoder(&buffer)
+	for _, tick := range testTicks() {
+		jsonEncoder.Encode(jsonTick{Currency: tick.Currency().Code, Amount: tick.GetStringValue()})
+	}
+	data := buffer.Bytes()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		for {
+			var tick jsonTick
+			if err := decoder.Decode(&tick); err != nil {
+				break
+			}
+			currency, _ := LookupCurrency(tick.Currency)
+			ParseAmount(currency, tick.Amount)
+		}
+	}
+}
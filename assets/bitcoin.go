@@ -1,49 +1,79 @@
 package assets
 
-import (
-	"bytes"
-	"math"
-	"strconv"
-	"strings"
-	"unicode/utf8"
-)
+import "math/big"
 
 const (
-	btcSizeSeparator        = "."
 	btcStringFractionLength = 8
 	btcIntFractionLength    = 8
 )
 
-var btcCoinMultiplier = int64(math.Pow10(btcIntFractionLength))
-
 func (bitcoin bitcoinStruct) GetStringValue() string {
 	return bitcoin.stringValue
 }
 
 func (bitcoin bitcoinStruct) GetIntValue() int64 {
-	return bitcoin.intValue
+	return bitcoin.amount.GetIntValue()
+}
+
+func (bitcoin bitcoinStruct) Amount() Amount {
+	return bitcoin.amount
+}
+
+// fromAmount a bitcoin holding the amount, sealing Quantity to the types in this package
+func (bitcoin bitcoinStruct) fromAmount(amount Amount) Bitcoin {
+	return newBitcoinFromAmount(amount)
+}
+
+func (bitcoin bitcoinStruct) bigIntValue() *big.Int {
+	return bitcoin.amount.bigIntValue()
 }
 
+// Add add, saturating at the int64 limits, use AddChecked to detect overflow
 func (bitcoin bitcoinStruct) Add(bitcoinToAdd Bitcoin) Bitcoin {
-	satoshis := bitcoin.GetIntValue()
-	satoshis += bitcoinToAdd.GetIntValue()
-	return NewBitcoinFromInt(satoshis)
+	return newBitcoinFromAmount(bitcoin.amount.add(bitcoinToAdd.Amount()))
+}
+
+// AddChecked add, returning an OverflowError instead of saturating
+func (bitcoin bitcoinStruct) AddChecked(bitcoinToAdd Bitcoin) (Bitcoin, error) {
+	return newBitcoinFromAmountChecked(bitcoin.amount.add(bitcoinToAdd.Amount()), "bitcoin add")
 }
 
+// Subtract subtract, saturating at the int64 limits, use SubtractChecked to detect overflow
 func (bitcoin bitcoinStruct) Subtract(bitcoinToSubtract Bitcoin) Bitcoin {
-	satoshis := bitcoin.GetIntValue()
-	satoshis -= bitcoinToSubtract.GetIntValue()
-	return NewBitcoinFromInt(satoshis)
+	return newBitcoinFromAmount(bitcoin.amount.subtract(bitcoinToSubtract.Amount()))
+}
+
+// SubtractChecked subtract, returning an OverflowError instead of saturating
+func (bitcoin bitcoinStruct) SubtractChecked(bitcoinToSubtract Bitcoin) (Bitcoin, error) {
+	return newBitcoinFromAmountChecked(bitcoin.amount.subtract(bitcoinToSubtract.Amount()), "bitcoin subtract")
 }
 
 func (bitcoin bitcoinStruct) GetCost(price USD) USD {
-	cost := price.GetIntValue() * bitcoin.GetIntValue() / btcCoinMultiplier
-	return NewUSDFromInt(cost)
+	return bitcoin.GetCostRounded(price, RoundTruncate)
+}
+
+// GetCostRounded get cost, rounding fractions of a cent with the rounding mode
+func (bitcoin bitcoinStruct) GetCostRounded(price USD, mode RoundingMode) USD {
+	return newUSDFromAmount(bitcoin.amount.GetCostRounded(price.Amount(), mode))
+}
+
+// GetCostChecked get cost, returning an OverflowError if the cost does not fit in USD
+func (bitcoin bitcoinStruct) GetCostChecked(price USD) (USD, error) {
+	return newUSDFromAmountChecked(bitcoin.amount.GetCostRounded(price.Amount(), RoundTruncate), "bitcoin cost")
 }
 
 func (bitcoin bitcoinStruct) Multiply(value int64, fractionLength int64) Bitcoin {
-	percentMultiplier := int64(math.Pow10(int(fractionLength)))
-	return NewBitcoinFromInt(bitcoin.GetIntValue() * value / percentMultiplier)
+	return bitcoin.MultiplyRounded(value, fractionLength, RoundTruncate)
+}
+
+// MultiplyRounded multiply, rounding fractions of a satoshi with the rounding mode
+func (bitcoin bitcoinStruct) MultiplyRounded(value int64, fractionLength int64, mode RoundingMode) Bitcoin {
+	return newBitcoinFromAmount(bitcoin.amount.MultiplyRounded(value, fractionLength, mode))
+}
+
+// MultiplyChecked multiply, returning an OverflowError if the product does not fit in bitcoin
+func (bitcoin bitcoinStruct) MultiplyChecked(value int64, fractionLength int64) (Bitcoin, error) {
+	return newBitcoinFromAmountChecked(bitcoin.amount.MultiplyRounded(value, fractionLength, RoundTruncate), "bitcoin multiply")
 }
 
 func (bitcoin bitcoinStruct) GetFractionLength() int64 {
@@ -52,31 +82,57 @@ func (bitcoin bitcoinStruct) GetFractionLength() int64 {
 
 // Compare sort by amount ascending
 func (bitcoin bitcoinStruct) Compare(other Bitcoin) int {
-	if bitcoin.GetIntValue() > other.GetIntValue() {
-		return 1
-	} else if bitcoin.GetIntValue() < other.GetIntValue() {
-		return -1
-	}
-	return 0
+	return bitcoin.amount.compare(other.Amount())
 }
 
+// Abs the bitcoin without its sign, the most negative bitcoin saturates at the largest
+func (bitcoin bitcoinStruct) Abs() Bitcoin {
+	return newBitcoinFromAmount(bitcoin.amount.Abs())
+}
+
+// Neg the bitcoin with its sign flipped, the most negative bitcoin saturates at the largest
+func (bitcoin bitcoinStruct) Neg() Bitcoin {
+	return newBitcoinFromAmount(bitcoin.amount.Neg())
+}
+
+// Sign -1, 0 or 1 for negative, zero and positive bitcoin
+func (bitcoin bitcoinStruct) Sign() int {
+	return bitcoin.amount.Sign()
+}
+
+// IsZero true when the bitcoin is zero
+func (bitcoin bitcoinStruct) IsZero() bool {
+	return bitcoin.amount.IsZero()
+}
+
+// IsNegative true when the bitcoin is less than zero
+func (bitcoin bitcoinStruct) IsNegative() bool {
+	return bitcoin.amount.IsNegative()
+}
+
+// GetUnitCostAtPrice get the cost of one bitcoin when this amount costs price, zero for zero bitcoin
 func (bitcoin bitcoinStruct) GetUnitCostAtPrice(price USD) USD {
-	ratio := float64(price.GetIntValue()) / float64(bitcoin.intValue)
-	multiplier := math.Pow10(int(btcIntFractionLength))
-	unitCostFloat := ratio * multiplier
-	unitCostInt := int64(math.Round(unitCostFloat))
-	return NewUSDFromInt(unitCostInt)
+	return bitcoin.GetUnitCostAtPriceRounded(price, RoundHalfUp)
+}
+
+// GetUnitCostAtPriceRounded get unit cost, rounding fractions of a cent with the rounding mode
+func (bitcoin bitcoinStruct) GetUnitCostAtPriceRounded(price USD, mode RoundingMode) USD {
+	return newUSDFromAmount(bitcoin.amount.GetUnitCostAtPriceRounded(price.Amount(), mode))
 }
 
 //NewBitcoinFromString create new bitcoin based on string value
 func NewBitcoinFromString(btcString string) (Bitcoin, error) {
-	btcString = standardizeBtcString(btcString)
-	btcInt, err := btcStringToInt(btcString)
+	return NewBitcoinFromStringRounded(btcString, RoundHalfUp)
+}
+
+// NewBitcoinFromStringRounded create new bitcoin based on string value, rounding fractions of a satoshi with the rounding mode
+func NewBitcoinFromStringRounded(btcString string, mode RoundingMode) (Bitcoin, error) {
+	btcString = standardizeAmountString(btcString, btcStringFractionLength)
+	amount, err := ParseAmountRounded(CurrencyBTC, btcString, mode)
 	if err != nil {
 		return nil, err
 	}
-	bitcoin := bitcoinStruct{stringValue: btcString, intValue: btcInt}
-	return bitcoin, nil
+	return newBitcoinFromAmountChecked(amount, "bitcoin conversion")
 }
 
 // ZeroBitcoin returns a bitcoin with value zero
@@ -86,104 +142,25 @@ func ZeroBitcoin() Bitcoin {
 
 //NewBitcoinFromInt create a new bitcoin based on int value
 func NewBitcoinFromInt(btcInt int64) Bitcoin {
-	btcString := btcIntToString(btcInt)
-	return bitcoinStruct{stringValue: btcString, intValue: btcInt}
+	return newBitcoinFromAmount(NewAmountFromInt(CurrencyBTC, btcInt))
 }
 
-func standardizeBtcString(btcString string) string {
-	pieces := strings.Split(btcString, btcSizeSeparator)
-	fractionString := "0"
-	if len(pieces) > 1 {
-		fractionString = pieces[1]
-	}
-	fractionLength := utf8.RuneCountInString(fractionString)
-	var fractionBuffer bytes.Buffer
-	fractionBuffer.WriteString(fractionString)
-	for i := fractionLength; i < btcStringFractionLength; i++ {
-		fractionBuffer.WriteString("0")
-	}
-	var standardizedBuffer bytes.Buffer
-	standardizedBuffer.WriteString(pieces[0])
-	standardizedBuffer.WriteString(btcSizeSeparator)
-	standardizedBuffer.WriteString(fractionBuffer.String())
-	return standardizedBuffer.String()
-}
-
-func btcStringToInt(btcString string) (int64, error) {
-	if len(btcString) < 1 {
-		return 0, ConversionError{message: "Empty string passed for bitcoin conversion [" + btcString + "]"}
-	}
-	pieces := strings.Split(btcString, btcSizeSeparator)
-	fraction, err := convertBtcFractionStringToInt(pieces[1])
-	if err != nil {
-		return 0, ConversionError{message: "Error converting fraction bitcoin [" + pieces[1] + "] for string [" + btcString + "] -- [" + err.Error() + "]"}
-	}
-	wholeCoins, err := convertWholeBtcToInt(pieces)
-	if err != nil {
-		return 0, ConversionError{message: "Error converting whole bitcoin [" + pieces[0] + "] for string [" + btcString + "] -- [" + err.Error() + "]"}
+// NewBitcoinFromAmount create a new bitcoin from an amount denominated in BTC
+func NewBitcoinFromAmount(amount Amount) (Bitcoin, error) {
+	if err := checkCurrencyDescriptor("bitcoin conversion", CurrencyBTC, amount.Currency()); err != nil {
+		return nil, err
 	}
-	return wholeCoins + fraction, nil
+	return newBitcoinFromAmountChecked(amount, "bitcoin conversion")
 }
 
-// add padding to get to btcFractionLength decimals then convert to int64
-func convertBtcFractionStringToInt(fractionString string) (int64, error) {
-	fraction, err := strconv.Atoi(fractionString)
-	if err != nil {
-		return 0, err
-	}
-	return roundBitcoinFromStringRepresentation(int64(fraction), len(fractionString)), nil
+func newBitcoinFromAmount(amount Amount) Bitcoin {
+	amount = saturateInt64(amount)
+	return bitcoinStruct{stringValue: amount.GetStringValue(), amount: amount}
 }
 
-func convertWholeBtcToInt(pieces []string) (int64, error) {
-	if pieces[0] == "" {
-		return 0, nil
-	}
-	wholeCoins, err := strconv.Atoi(pieces[0])
-	if err != nil {
-		return 0, err
-	}
-	return int64(wholeCoins) * btcCoinMultiplier, nil
-}
-
-func btcIntToString(btcInt int64) string {
-	isNegative := btcInt < 0
-	if isNegative {
-		btcInt = -1 * btcInt
-	}
-	wholeBtc := btcInt / int64(btcCoinMultiplier)
-	fractionBtc := btcInt % int64(btcCoinMultiplier)
-	fractionString := strconv.FormatInt(fractionBtc, 10)
-	fractionLength := utf8.RuneCountInString(fractionString)
-	var buffer bytes.Buffer
-	for i := fractionLength; i < btcStringFractionLength; i++ {
-		buffer.WriteString("0")
-	}
-	btcString := strconv.FormatInt(wholeBtc, 10) + btcSizeSeparator + buffer.String() + fractionString
-	if isNegative {
-		btcString = "-" + btcString
-	}
-	return btcString
-}
-
-func roundBitcoinFromStringRepresentation(btcInt int64, fractionLength int) int64 {
-	return roundBitcoin(btcInt, int64(fractionLength), btcIntFractionLength)
-}
-
-func roundBitcoin(btcInt, fractionLength, significantDigits int64) int64 {
-	roundingPlace := fractionLength - significantDigits
-	significantModulus := int64(10)
-	roundingValueMultiplier := 1
-	for i := int64(1); i <= roundingPlace; i++ {
-		currentRoundingValue := btcInt % significantModulus
-		if currentRoundingValue >= int64(roundingValueMultiplier*5) {
-			valueToAdd := significantModulus - currentRoundingValue
-			btcInt += valueToAdd
-		} else {
-			btcInt -= currentRoundingValue
-		}
-		significantModulus = significantModulus * 10
-		roundingValueMultiplier = roundingValueMultiplier * 10
+func newBitcoinFromAmountChecked(amount Amount, operation string) (Bitcoin, error) {
+	if _, err := bigToInt64Checked(amount.minorUnits(), operation); err != nil {
+		return nil, err
 	}
-	divisor := int64(math.Pow10(int(roundingPlace)))
-	return btcInt / divisor
+	return newBitcoinFromAmount(amount), nil
 }
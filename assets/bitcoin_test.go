@@ -1,6 +1,9 @@
 package assets
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func TestBitcoin(t *testing.T) {
 	btc, _ := NewBitcoinFromString("1.20500282")
@@ -14,6 +17,16 @@ func TestBitcoin(t *testing.T) {
 	}
 }
 
+func TestZeroBitcoinUnitCost(t *testing.T) {
+	usd, _ := NewUSDFromString("7055.38")
+	if unitCost := ZeroBitcoin().GetUnitCostAtPrice(usd); !unitCost.IsZero() {
+		t.Errorf("Expected zero unit cost but got %s", unitCost.GetStringValue())
+	}
+	if _, err := NewAmountFromInt(CurrencyBTC, 0).GetUnitCostAtPriceRounded(usd.Amount(), RoundCeiling).Add(usd.Amount()); err != nil {
+		t.Errorf("Expected a USD amount %v", err)
+	}
+}
+
 func TestNegativeBitcoin(t *testing.T) {
 	btc, err := NewBitcoinFromString("-1.00")
 	if err != nil {
@@ -24,3 +37,102 @@ func TestNegativeBitcoin(t *testing.T) {
 	}
 
 }
+
+func TestBitcoinCostDoesNotOverflow(t *testing.T) {
+	btc, _ := NewBitcoinFromString("500")
+	price, _ := NewUSDFromString("60000.00")
+	cost, err := btc.GetCostChecked(price)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if cost.GetStringValue() != "30000000.00" {
+		t.Errorf("Invalid cost %s", cost.GetStringValue())
+	}
+	if btc.GetCost(price).Compare(cost) != 0 {
+		t.Errorf("Unchecked cost %s differs from checked cost", btc.GetCost(price).GetStringValue())
+	}
+	doubled := btc.Multiply(2, 0)
+	if doubled.GetStringValue() != "1000.00000000" {
+		t.Errorf("Invalid multiply result %s", doubled.GetStringValue())
+	}
+}
+
+func TestBitcoinUncheckedSaturates(t *testing.T) {
+	max := NewBitcoinFromInt(math.MaxInt64)
+	min := NewBitcoinFromInt(math.MinInt64)
+	cases := []struct {
+		actual   Bitcoin
+		expected Bitcoin
+	}{
+		{max.Add(NewBitcoinFromInt(1)), max},
+		{min.Subtract(NewBitcoinFromInt(1)), min},
+		{min.Abs(), max},
+		{min.Neg(), max},
+		{max.Multiply(2, 0), max},
+	}
+	for _, c := range cases {
+		if c.actual.GetIntValue() != c.expected.GetIntValue() || c.actual.GetStringValue() != c.expected.GetStringValue() {
+			t.Errorf("Expected %s (%d) but got %s (%d)", c.expected.GetStringValue(), c.expected.GetIntValue(), c.actual.GetStringValue(), c.actual.GetIntValue())
+		}
+	}
+	usd := NewUSDFromInt(math.MaxInt64).Add(NewUSDFromInt(1))
+	if usd.GetIntValue() != math.MaxInt64 || usd.GetStringValue() != "92233720368547758.07" {
+		t.Errorf("Expected saturated usd but got %s (%d)", usd.GetStringValue(), usd.GetIntValue())
+	}
+}
+
+func TestBitcoinOverflowErrors(t *testing.T) {
+	btc := NewBitcoinFromInt(math.MaxInt64)
+	if _, err := btc.AddChecked(NewBitcoinFromInt(1)); err == nil {
+		t.Error("Expected overflow adding to max bitcoin")
+	} else if _, ok := err.(OverflowError); !ok {
+		t.Errorf("Expected OverflowError but got %v", err)
+	}
+	if _, err := NewBitcoinFromInt(math.MinInt64).SubtractChecked(NewBitcoinFromInt(1)); err == nil {
+		t.Error("Expected overflow subtracting from min bitcoin")
+	}
+	if _, err := btc.MultiplyChecked(2, 0); err == nil {
+		t.Error("Expected overflow multiplying max bitcoin")
+	}
+	price, _ := NewUSDFromString("1000000000.00")
+	if _, err := btc.GetCostChecked(price); err == nil {
+		t.Error("Expected overflow getting cost of max bitcoin")
+	}
+	if _, err := NewBitcoinFromString("100000000000"); err == nil {
+		t.Error("Expected overflow parsing bitcoin")
+	} else if _, ok := err.(OverflowError); !ok {
+		t.Errorf("Expected OverflowError but got %v", err)
+	}
+	if NewBitcoinFromInt(math.MinInt64).GetStringValue() != "-92233720368.54775808" {
+		t.Errorf("Invalid min bitcoin string %s", NewBitcoinFromInt(math.MinInt64).GetStringValue())
+	}
+}
+
+func TestBitcoinRoundingModes(t *testing.T) {
+	halfUp, _ := NewBitcoinFromString("0.123456785")
+	if halfUp.GetIntValue() != 12345679 {
+		t.Errorf("Invalid default rounding %d", halfUp.GetIntValue())
+	}
+	halfEven, _ := NewBitcoinFromStringRounded("0.123456785", RoundHalfEven)
+	if halfEven.GetIntValue() != 12345678 {
+		t.Errorf("Invalid half even rounding %d", halfEven.GetIntValue())
+	}
+	floor, _ := NewBitcoinFromStringRounded("0.123456789", RoundFloor)
+	if floor.GetIntValue() != 12345678 {
+		t.Errorf("Invalid floor rounding %d", floor.GetIntValue())
+	}
+	btc := NewBitcoinFromInt(3)
+	if btc.MultiplyRounded(5, 1, RoundCeiling).GetIntValue() != 2 {
+		t.Errorf("Invalid ceiling multiply %d", btc.MultiplyRounded(5, 1, RoundCeiling).GetIntValue())
+	}
+	price, _ := NewUSDFromString("0.99")
+	half, _ := NewBitcoinFromString("0.5")
+	if half.GetCost(price).GetIntValue() != 49 || half.GetCostRounded(price, RoundHalfUp).GetIntValue() != 50 {
+		t.Errorf("Invalid cost rounding %s", half.GetCostRounded(price, RoundHalfUp).GetStringValue())
+	}
+	three, _ := NewBitcoinFromString("3")
+	if three.GetUnitCostAtPriceRounded(NewUSDFromInt(100), RoundFloor).GetIntValue() != 33 {
+		t.Errorf("Invalid unit cost rounding %s", three.GetUnitCostAtPriceRounded(NewUSDFromInt(100), RoundFloor).GetStringValue())
+	}
+}
@@ -0,0 +1,106 @@
+package assets
+
+import (
+	"strconv"
+	"sync"
+)
+
+// Currency describes a currency amounts can be denominated in
+type Currency struct {
+	// Code unique identifier such as BTC or USD
+	Code string
+	// Symbol printed next to formatted amounts
+	Symbol string
+	// Decimals number of fraction digits held by the minor unit
+	Decimals int64
+	// DisplayDecimals number of fraction digits used by pretty strings
+	DisplayDecimals int64
+}
+
+var (
+	// CurrencyBTC bitcoin, held in satoshis
+	CurrencyBTC = Currency{Code: "BTC", Symbol: "₿", Decimals: btcIntFractionLength, DisplayDecimals: btcIntFractionLength}
+	// CurrencyETH ether, held in wei
+	CurrencyETH = Currency{Code: "ETH", Symbol: "Ξ", Decimals: ethIntFractionLength, DisplayDecimals: ethIntFractionLength}
+	// CurrencyUSD us dollar, held in cents
+	CurrencyUSD = Currency{Code: "USD", Symbol: "$", Decimals: usdIntFractionLength, DisplayDecimals: usdIntFractionLength}
+	// CurrencyEUR euro, held in cents
+	CurrencyEUR = Currency{Code: "EUR", Symbol: "€", Decimals: 2, DisplayDecimals: 2}
+	// CurrencyGBP pound sterling, held in pence
+	CurrencyGBP = Currency{Code: "GBP", Symbol: "£", Decimals: 2, DisplayDecimals: 2}
+	// CurrencyJPY japanese yen, which has no minor unit
+	CurrencyJPY = Currency{Code: "JPY", Symbol: "¥", Decimals: 0, DisplayDecimals: 0}
+	// CurrencyKWD kuwaiti dinar, held in fils
+	CurrencyKWD = Currency{Code: "KWD", Symbol: "KD", Decimals: 3, DisplayDecimals: 3}
+	// CurrencyCHF swiss franc, held in rappen
+	CurrencyCHF = Currency{Code: "CHF", Symbol: "CHF", Decimals: 2, DisplayDecimals: 2}
+	// CurrencyCAD canadian dollar, held in cents
+	CurrencyCAD = Currency{Code: "CAD", Symbol: "CA$", Decimals: 2, DisplayDecimals: 2}
+)
+
+// maxCurrencyDecimals most fraction digits a currency can hold, token decimals are a uint8
+const maxCurrencyDecimals = 255
+
+// CurrencyError invalid currency registration or operation across currencies
+type CurrencyError struct {
+	message string
+}
+
+func (err CurrencyError) Error() string {
+	return err.message
+}
+
+var currencyRegistry = struct {
+	sync.RWMutex
+	currencies map[string]Currency
+}{currencies: map[string]Currency{
+	CurrencyBTC.Code: CurrencyBTC,
+	CurrencyETH.Code: CurrencyETH,
+	CurrencyUSD.Code: CurrencyUSD,
+	CurrencyEUR.Code: CurrencyEUR,
+	CurrencyGBP.Code: CurrencyGBP,
+	CurrencyJPY.Code: CurrencyJPY,
+	CurrencyKWD.Code: CurrencyKWD,
+	CurrencyCHF.Code: CurrencyCHF,
+	CurrencyCAD.Code: CurrencyCAD,
+}}
+
+// RegisterCurrency register a currency so it can be looked up by code, registering the same descriptor twice is a no-op
+func RegisterCurrency(currency Currency) error {
+	if currency.Code == "" {
+		return CurrencyError{message: "Currency code is required"}
+	}
+	if currency.Decimals < 0 || currency.DisplayDecimals < 0 || currency.Decimals > maxCurrencyDecimals || currency.DisplayDecimals > maxCurrencyDecimals {
+		return CurrencyError{message: "Invalid decimals for currency [" + currency.Code + "] -- [" + strconv.FormatInt(currency.Decimals, 10) + ", " + strconv.FormatInt(currency.DisplayDecimals, 10) + "]"}
+	}
+	currencyRegistry.Lock()
+	defer currencyRegistry.Unlock()
+	if existing, ok := currencyRegistry.currencies[currency.Code]; ok && existing != currency {
+		return CurrencyError{message: "Currency [" + currency.Code + "] is already registered with a different descriptor"}
+	}
+	currencyRegistry.currencies[currency.Code] = currency
+	return nil
+}
+
+// LookupCurrency find a registered currency by code
+func LookupCurrency(code string) (Currency, bool) {
+	currencyRegistry.RLock()
+	defer currencyRegistry.RUnlock()
+	currency, ok := currencyRegistry.currencies[code]
+	return currency, ok
+}
+
+func newCurrencyMismatchError(operation string, expected, actual Currency) CurrencyError {
+	return CurrencyError{message: "Currency mismatch in " + operation + " -- expected [" + expected.Code + "] but got [" + actual.Code + "]"}
+}
+
+// the actual currency must be the expected descriptor, not only share its code
+func checkCurrencyDescriptor(operation string, expected, actual Currency) error {
+	if actual == expected {
+		return nil
+	}
+	if actual.Code != expected.Code {
+		return newCurrencyMismatchError(operation, expected, actual)
+	}
+	return CurrencyError{message: "Currency [" + actual.Code + "] in " + operation + " does not match the registered descriptor"}
+}
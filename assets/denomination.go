@@ -0,0 +1,199 @@
+package assets
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Denomination unit an amount of a currency can be written in, 150 sats or 1.5 mBTC
+type Denomination int
+
+const (
+	// DenominationBTC whole bitcoin
+	DenominationBTC Denomination = iota + 1
+	// DenominationMilliBTC thousandths of a bitcoin, 100,000 satoshis
+	DenominationMilliBTC
+	// DenominationMicroBTC millionths of a bitcoin, 100 satoshis
+	DenominationMicroBTC
+	// DenominationBits millionths of a bitcoin under their wallet name
+	DenominationBits
+	// DenominationSatoshi the smallest on-chain unit
+	DenominationSatoshi
+	// DenominationMillisatoshi thousandths of a satoshi as used by lightning
+	DenominationMillisatoshi
+	// DenominationEther whole ether
+	DenominationEther
+	// DenominationFinney thousandths of an ether
+	DenominationFinney
+	// DenominationSzabo millionths of an ether
+	DenominationSzabo
+	// DenominationGwei billionths of an ether, the usual unit of gas prices
+	DenominationGwei
+	// DenominationMwei a million wei
+	DenominationMwei
+	// DenominationKwei a thousand wei
+	DenominationKwei
+	// DenominationWei the smallest unit of ether
+	DenominationWei
+)
+
+type denominationInfo struct {
+	currency Currency
+	name     string
+	aliases  []string
+	// exponent number of decimal digits one unit is below a whole unit of the currency
+	exponent int64
+}
+
+var denominations = map[Denomination]denominationInfo{
+	DenominationBTC:          {currency: CurrencyBTC, name: "BTC", aliases: []string{"btc"}, exponent: 0},
+	DenominationMilliBTC:     {currency: CurrencyBTC, name: "mBTC", exponent: 3},
+	DenominationMicroBTC:     {currency: CurrencyBTC, name: "µBTC", aliases: []string{"uBTC"}, exponent: 6},
+	DenominationBits:         {currency: CurrencyBTC, name: "bits", aliases: []string{"bit"}, exponent: 6},
+	DenominationSatoshi:      {currency: CurrencyBTC, name: "sats", aliases: []string{"sat", "satoshi", "satoshis"}, exponent: 8},
+	DenominationMillisatoshi: {currency: CurrencyBTC, name: "msat", aliases: []string{"msats", "millisatoshi", "millisatoshis"}, exponent: 11},
+	DenominationEther:        {currency: CurrencyETH, name: "ether", aliases: []string{"ETH", "eth"}, exponent: 0},
+	DenominationFinney:       {currency: CurrencyETH, name: "finney", aliases: []string{"milliether"}, exponent: 3},
+	DenominationSzabo:        {currency: CurrencyETH, name: "szabo", aliases: []string{"microether"}, exponent: 6},
+	DenominationGwei:         {currency: CurrencyETH, name: "gwei", aliases: []string{"Gwei", "shannon"}, exponent: gweiFractionLength},
+	DenominationMwei:         {currency: CurrencyETH, name: "mwei", aliases: []string{"Mwei", "lovelace"}, exponent: 12},
+	DenominationKwei:         {currency: CurrencyETH, name: "kwei", aliases: []string{"Kwei", "babbage"}, exponent: 15},
+	DenominationWei:          {currency: CurrencyETH, name: "wei", exponent: ethIntFractionLength},
+}
+
+func (denomination Denomination) String() string {
+	if info, ok := denominations[denomination]; ok {
+		return info.name
+	}
+	return "unknown denomination " + strconv.Itoa(int(denomination))
+}
+
+// Currency the currency the denomination is a unit of
+func (denomination Denomination) Currency() Currency {
+	return denominations[denomination].currency
+}
+
+// Exponent number of decimal digits one unit of the denomination is below a whole unit of its currency
+func (denomination Denomination) Exponent() int64 {
+	return denominations[denomination].exponent
+}
+
+// LookupDenomination find a denomination of the currency by name or alias, sats or satoshi
+func LookupDenomination(currency Currency, unit string) (Denomination, bool) {
+	for denomination, info := range denominations {
+		if info.currency.Code != currency.Code {
+			continue
+		}
+		if info.name == unit {
+			return denomination, true
+		}
+		for _, alias := range info.aliases {
+			if alias == unit {
+				return denomination, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// Format the amount in the denomination, dropping trailing fraction zeros, 150 sats
+func (denomination Denomination) Format(amount Amount) (string, error) {
+	return denomination.FormatLocale(amount, Locale{DecimalMark: amountSeparator, SymbolPlacement: SymbolNone})
+}
+
+// FormatLocale the amount in the denomination, grouping digits with the locale, 2,000 bits
+func (denomination Denomination) FormatLocale(amount Amount, locale Locale) (string, error) {
+	if amount.currency.Code != denomination.Currency().Code {
+		return "", newCurrencyMismatchError("denomination format", denomination.Currency(), amount.currency)
+	}
+	return denomination.formatScaled(amount.minorUnits(), amount.currency.Decimals, locale), nil
+}
+
+// format a value held in units minorExponent digits below a whole unit of the currency
+func (denomination Denomination) formatScaled(value *big.Int, minorExponent int64, locale Locale) string {
+	fractionDigits := minorExponent - denomination.Exponent()
+	if fractionDigits < 0 {
+		value = new(big.Int).Mul(value, pow10Big(-fractionDigits))
+		fractionDigits = 0
+	}
+	// drop trailing zeros so 150000000 sats prints as 1.5 BTC rather than 1.50000000 BTC
+	ten := big.NewInt(10)
+	for fractionDigits > 0 && new(big.Int).Rem(value, ten).Sign() == 0 {
+		value = new(big.Int).Quo(value, ten)
+		fractionDigits--
+	}
+	locale.SymbolPlacement = SymbolNone
+	number := locale.FormatDecimals(NewAmount(Currency{Code: denomination.String(), Decimals: fractionDigits}, value), fractionDigits)
+	return number + " " + denomination.String()
+}
+
+// ParseDenominated parse an amount of the currency written with a denomination, 150 sats or 2,000 bits,
+// rounding units finer than the currency holds with the rounding mode
+func ParseDenominated(currency Currency, denominated string, mode RoundingMode) (Amount, error) {
+	value, err := parseScaled(currency, denominated, currency.Decimals, mode)
+	if err != nil {
+		return Amount{}, err
+	}
+	return NewAmount(currency, value), nil
+}
+
+// ParseBitcoinDenominated create bitcoin from a string with a denomination, rounding fractions of a satoshi half up
+func ParseBitcoinDenominated(denominated string) (Bitcoin, error) {
+	return ParseBitcoinDenominatedRounded(denominated, RoundHalfUp)
+}
+
+// ParseBitcoinDenominatedRounded create bitcoin from a string with a denomination, rounding fractions of a satoshi with the rounding mode
+func ParseBitcoinDenominatedRounded(denominated string, mode RoundingMode) (Bitcoin, error) {
+	amount, err := ParseDenominated(CurrencyBTC, denominated, mode)
+	if err != nil {
+		return nil, err
+	}
+	return newBitcoinFromAmountChecked(amount, "bitcoin conversion")
+}
+
+// ParseEtherDenominated create ether from a string with a denomination, 21 gwei or 1000000000000000000 wei,
+// truncating fractions of a wei
+func ParseEtherDenominated(denominated string) (Ether, error) {
+	return ParseEtherDenominatedRounded(denominated, RoundTruncate)
+}
+
+// ParseEtherDenominatedRounded create ether from a string with a denomination, rounding fractions of a wei with the rounding mode
+func ParseEtherDenominatedRounded(denominated string, mode RoundingMode) (Ether, error) {
+	amount, err := ParseDenominated(CurrencyETH, denominated, mode)
+	if err != nil {
+		return nil, err
+	}
+	return newEtherFromAmount(amount), nil
+}
+
+// parse a denominated string into units minorExponent digits below a whole unit of the currency.
+// The number is strict apart from allowing whole digits grouped with commas
+func parseScaled(currency Currency, denominated string, minorExponent int64, mode RoundingMode) (*big.Int, error) {
+	denominated = strings.TrimFunc(denominated, unicode.IsSpace)
+	split := strings.LastIndexFunc(denominated, unicode.IsSpace)
+	if split < 0 {
+		return nil, ConversionError{message: "Missing denomination for " + currency.Code + " [" + denominated + "]"}
+	}
+	number := strings.TrimFunc(denominated[:split], unicode.IsSpace)
+	unit := denominated[split+1:]
+	denomination, ok := LookupDenomination(currency, unit)
+	if !ok {
+		return nil, ConversionError{message: "Unknown " + currency.Code + " denomination [" + unit + "]"}
+	}
+	fractionDigits := int64(0)
+	if _, fraction, found := strings.Cut(number, amountSeparator); found {
+		fractionDigits = int64(len(fraction))
+	}
+	parsed, err := LocaleEnUS.Parse(Currency{Code: denomination.String(), Decimals: fractionDigits}, number)
+	if err != nil {
+		return nil, err
+	}
+	// value in minor units = parsed / 10^fractionDigits * 10^(minorExponent - exponent)
+	shift := minorExponent - denomination.Exponent() - fractionDigits
+	if shift >= 0 {
+		return new(big.Int).Mul(parsed.minorUnits(), pow10Big(shift)), nil
+	}
+	return quoRound(parsed.minorUnits(), pow10Big(-shift), mode), nil
+}
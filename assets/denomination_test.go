@@ -0,0 +1,175 @@
+package assets
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseBitcoinDenominated(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"150 sats", 150},
+		{"1 sat", 1},
+		{"1.5 mBTC", 150000},
+		{"2,000 bits", 200000},
+		{"2.5 µBTC", 250},
+		{"3 uBTC", 300},
+		{"0.00000001 BTC", 1},
+		{"-1.25 BTC", -125000000},
+		{"1500 msat", 2},
+		{"1499 msat", 1},
+		{"  21 sats  ", 21},
+	}
+	for _, test := range tests {
+		bitcoin, err := ParseBitcoinDenominated(test.input)
+		if err != nil || bitcoin.GetIntValue() != test.expected {
+			t.Errorf("Invalid parse of %q: %v %v, expected %d", test.input, bitcoin, err, test.expected)
+		}
+	}
+	truncated, _ := ParseBitcoinDenominatedRounded("1999 msat", RoundTruncate)
+	if truncated.GetIntValue() != 1 {
+		t.Errorf("Expected msat to truncate, got %d", truncated.GetIntValue())
+	}
+}
+
+func TestParseBitcoinDenominatedErrors(t *testing.T) {
+	for _, input := range []string{"150", "150 wei", "1.2.3 sats", "20,00 bits", "", "sats"} {
+		if _, err := ParseBitcoinDenominated(input); err == nil {
+			t.Errorf("Expected error parsing %q", input)
+		}
+	}
+	if _, err := ParseBitcoinDenominated("+1 sats"); !errors.Is(err, ErrInvalidCharacter) && !errors.Is(err, ErrLeadingPlus) {
+		t.Errorf("Expected strict parse error, got %v", err)
+	}
+}
+
+func TestDenominationFormat(t *testing.T) {
+	bitcoin, _ := NewBitcoinFromString("1.5")
+	tests := []struct {
+		denomination Denomination
+		expected     string
+	}{
+		{DenominationBTC, "1.5 BTC"},
+		{DenominationMilliBTC, "1500 mBTC"},
+		{DenominationBits, "1500000 bits"},
+		{DenominationMicroBTC, "1500000 µBTC"},
+		{DenominationSatoshi, "150000000 sats"},
+		{DenominationMillisatoshi, "150000000000 msat"},
+	}
+	for _, test := range tests {
+		formatted, err := test.denomination.Format(bitcoin.Amount())
+		if err != nil || formatted != test.expected {
+			t.Errorf("Invalid format %q %v, expected %q", formatted, err, test.expected)
+		}
+	}
+	grouped, _ := DenominationBits.FormatLocale(NewBitcoinFromInt(-200012).Amount(), LocaleEnUS)
+	if grouped != "-2,000.12 bits" {
+		t.Errorf("Invalid grouped format %q", grouped)
+	}
+	if _, err := DenominationSatoshi.Format(ZeroAmount(CurrencyUSD)); err == nil {
+		t.Error("Expected error formatting usd in sats")
+	}
+	if DenominationSatoshi.String() != "sats" || Denomination(99).String() != "unknown denomination 99" {
+		t.Errorf("Invalid denomination names %s %s", DenominationSatoshi, Denomination(99))
+	}
+}
+
+func TestLightningAmount(t *testing.T) {
+	lightning, err := ParseLightningAmount("1.5 sats")
+	if err != nil || lightning.Millisatoshis().Int64() != 1500 || lightning.String() != "1500 msat" {
+		t.Fatalf("Invalid lightning amount %v %v", lightning, err)
+	}
+	if lightning.IsWholeSatoshis() {
+		t.Error("Expected 1500 msat not to be whole satoshis")
+	}
+	if lightning.Bitcoin(RoundFloor).GetIntValue() != 1 || lightning.Bitcoin(RoundCeiling).GetIntValue() != 2 || lightning.Bitcoin(RoundHalfEven).GetIntValue() != 2 {
+		t.Errorf("Invalid rounding to bitcoin")
+	}
+	fromBitcoin := NewLightningAmountFromBitcoin(NewBitcoinFromInt(3))
+	if fromBitcoin.Millisatoshis().Int64() != 3000 || !fromBitcoin.IsWholeSatoshis() {
+		t.Errorf("Invalid conversion from bitcoin %v", fromBitcoin)
+	}
+	total := fromBitcoin.Add(lightning).Subtract(NewLightningAmount(500))
+	if total.Compare(NewLightningAmount(4000)) != 0 {
+		t.Errorf("Invalid total %v", total)
+	}
+	formatted, _ := total.Format(DenominationSatoshi)
+	if formatted != "4 sats" {
+		t.Errorf("Invalid format %q", formatted)
+	}
+	if (LightningAmount{}).String() != "0 msat" {
+		t.Errorf("Invalid zero value %q", LightningAmount{}.String())
+	}
+}
+
+func TestParseEtherDenominated(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"21 gwei", "21000000000"},
+		{"1000000000000000000 wei", "1000000000000000000"},
+		{"1.5 ether", "1500000000000000000"},
+		{"2 ETH", "2000000000000000000"},
+		{"3 finney", "3000000000000000"},
+		{"4 szabo", "4000000000000"},
+		{"5 mwei", "5000000"},
+		{"6 kwei", "6000"},
+		{"0.5 wei", "0"},
+		{"-1,000 gwei", "-1000000000000"},
+	}
+	for _, test := range tests {
+		ether, err := ParseEtherDenominated(test.input)
+		if err != nil || ether.Wei().String() != test.expected {
+			t.Errorf("Invalid parse of %q: %v %v, expected %s", test.input, ether, err, test.expected)
+		}
+	}
+	rounded, _ := ParseEtherDenominatedRounded("0.5 wei", RoundHalfUp)
+	if rounded.Wei().Int64() != 1 {
+		t.Errorf("Expected half a wei to round up, got %s", rounded.Wei())
+	}
+	if _, err := ParseEtherDenominated("21 sats"); err == nil {
+		t.Error("Expected error parsing ether in a bitcoin denomination")
+	}
+}
+
+func TestEtherDenominationFormat(t *testing.T) {
+	ether, _ := NewEtherFromString("0.000000021")
+	for denomination, expected := range map[Denomination]string{
+		DenominationEther: "0.000000021 ether",
+		DenominationGwei:  "21 gwei",
+		DenominationWei:   "21000000000 wei",
+		DenominationKwei:  "21000000 kwei",
+	} {
+		formatted, err := denomination.Format(ether.Amount())
+		if err != nil || formatted != expected {
+			t.Errorf("Invalid format %q %v, expected %q", formatted, err, expected)
+		}
+	}
+	if _, err := DenominationGwei.Format(ZeroAmount(CurrencyBTC)); err == nil {
+		t.Error("Expected error formatting bitcoin in gwei")
+	}
+}
+
+func TestGasPrice(t *testing.T) {
+	gasPrice, err := ParseGasPrice("21.5 gwei")
+	if err != nil || gasPrice.Wei().Int64() != 21500000000 || gasPrice.String() != "21.5 gwei" {
+		t.Fatalf("Invalid gas price %v %v", gasPrice, err)
+	}
+	fee := gasPrice.Fee(21000)
+	if fee.GetStringValue() != "0.000451500000000000" {
+		t.Errorf("Invalid fee %s", fee.GetStringValue())
+	}
+	if NewGasPriceFromGwei(21).Compare(gasPrice) != -1 || NewGasPrice(gasPrice.Wei()).Compare(gasPrice) != 0 {
+		t.Error("Invalid gas price comparison")
+	}
+	formatted, _ := gasPrice.Format(DenominationWei)
+	if formatted != "21500000000 wei" {
+		t.Errorf("Invalid format %q", formatted)
+	}
+	if _, err := gasPrice.Format(DenominationSatoshi); err == nil {
+		t.Error("Expected error formatting a gas price in sats")
+	}
+}
@@ -1,150 +1,149 @@
 package assets
 
-import (
-	"bytes"
-	"math"
-	"strconv"
-	"strings"
-	"unicode/utf8"
-)
+import "math/big"
 
 const (
-	ethSizeSeparator        = "."
-	ethStringFractionLength = 8
-	ethIntFractionLength    = 8
+	ethStringFractionLength = 18
+	ethIntFractionLength    = 18
+	gweiFractionLength      = 9
 )
 
-var ethCoinMultiplier = int64(math.Pow10(ethIntFractionLength))
+var weiPerGwei = pow10Big(gweiFractionLength)
 
 func (ether etherStruct) GetStringValue() string {
 	return ether.stringValue
 }
 
+// GetIntValue returns the value in wei, saturating at the int64 limits, use Wei for the exact value
 func (ether etherStruct) GetIntValue() int64 {
-	return ether.intValue
+	return ether.amount.GetIntValue()
+}
+
+func (ether etherStruct) Amount() Amount {
+	return ether.amount
+}
+
+// fromAmount a ether holding the amount, sealing Quantity to the types in this package
+func (ether etherStruct) fromAmount(amount Amount) Ether {
+	return newEtherFromAmount(amount)
+}
+
+// Wei returns the value in wei
+func (ether etherStruct) Wei() *big.Int {
+	return ether.amount.MinorUnits()
+}
+
+func (ether etherStruct) bigIntValue() *big.Int {
+	return ether.Wei()
+}
+
+// Gwei returns the value in whole gwei, truncating any remaining wei
+func (ether etherStruct) Gwei() *big.Int {
+	return new(big.Int).Quo(ether.amount.minorUnits(), weiPerGwei)
 }
 
 func (ether etherStruct) Add(etherToAdd Ether) Ether {
-	satoshis := ether.GetIntValue()
-	satoshis += etherToAdd.GetIntValue()
-	return NewEtherFromInt(satoshis)
+	return newEtherFromAmount(ether.amount.add(etherToAdd.Amount()))
 }
 
 func (ether etherStruct) Subtract(etherToSubtract Ether) Ether {
-	satoshis := ether.GetIntValue()
-	satoshis -= etherToSubtract.GetIntValue()
-	return NewEtherFromInt(satoshis)
+	return newEtherFromAmount(ether.amount.subtract(etherToSubtract.Amount()))
 }
 
 // Compare sort by amount ascending
 func (ether etherStruct) Compare(other Ether) int {
-	if ether.GetIntValue() > other.GetIntValue() {
-		return 1
-	} else if ether.GetIntValue() < other.GetIntValue() {
-		return -1
-	}
-	return 0
+	return ether.amount.compare(other.Amount())
+}
+
+// Abs the ether without its sign
+func (ether etherStruct) Abs() Ether {
+	return newEtherFromAmount(ether.amount.Abs())
+}
+
+// Neg the ether with its sign flipped
+func (ether etherStruct) Neg() Ether {
+	return newEtherFromAmount(ether.amount.Neg())
+}
+
+// Sign -1, 0 or 1 for negative, zero and positive ether
+func (ether etherStruct) Sign() int {
+	return ether.amount.Sign()
+}
+
+// IsZero true when the ether is zero
+func (ether etherStruct) IsZero() bool {
+	return ether.amount.IsZero()
+}
+
+// IsNegative true when the ether is less than zero
+func (ether etherStruct) IsNegative() bool {
+	return ether.amount.IsNegative()
 }
 
 func (ether etherStruct) GetCost(price USD) USD {
-	cost := price.GetIntValue() * ether.GetIntValue() / ethCoinMultiplier
-	return NewUSDFromInt(cost)
+	return ether.GetCostRounded(price, RoundTruncate)
+}
+
+// GetCostRounded get cost, rounding fractions of a cent with the rounding mode
+func (ether etherStruct) GetCostRounded(price USD, mode RoundingMode) USD {
+	return newUSDFromAmount(ether.amount.GetCostRounded(price.Amount(), mode))
+}
+
+// GetCostChecked get cost, returning an OverflowError if the cost does not fit in USD
+func (ether etherStruct) GetCostChecked(price USD) (USD, error) {
+	return newUSDFromAmountChecked(ether.amount.GetCostRounded(price.Amount(), RoundTruncate), "ether cost")
 }
 
 func (ether etherStruct) Multiply(value int64, fractionLength int64) Ether {
-	percentMultiplier := int64(math.Pow10(int(fractionLength)))
-	return NewEtherFromInt(ether.GetIntValue() * value / percentMultiplier)
+	return ether.MultiplyRounded(value, fractionLength, RoundTruncate)
+}
+
+// MultiplyRounded multiply, rounding fractions of a wei with the rounding mode
+func (ether etherStruct) MultiplyRounded(value int64, fractionLength int64, mode RoundingMode) Ether {
+	return newEtherFromAmount(ether.amount.MultiplyRounded(value, fractionLength, mode))
 }
 
 func (ether etherStruct) GetFractionLength() int64 {
 	return ethIntFractionLength
 }
 
-//NewEtherFromString create new ether based on string value
+//NewEtherFromString create new ether based on string value, truncating fractions of a wei
 func NewEtherFromString(ethString string) (Ether, error) {
-	ethString = standardizeEthString(ethString)
-	ethInt, err := ethStringToInt(ethString)
+	return NewEtherFromStringRounded(ethString, RoundTruncate)
+}
+
+// NewEtherFromStringRounded create new ether based on string value, rounding fractions of a wei with the rounding mode
+func NewEtherFromStringRounded(ethString string, mode RoundingMode) (Ether, error) {
+	amount, err := ParseAmountRounded(CurrencyETH, standardizeAmountString(ethString, ethStringFractionLength), mode)
 	if err != nil {
 		return nil, err
 	}
-	ether := etherStruct{stringValue: ethString, intValue: ethInt}
-	return ether, nil
+	return newEtherFromAmount(amount), nil
 }
 
-//NewEtherFromInt create a new ether based on int value
+//NewEtherFromInt create a new ether based on int value in wei
 func NewEtherFromInt(ethInt int64) Ether {
-	ethString := ethIntToString(ethInt)
-	return etherStruct{stringValue: ethString, intValue: ethInt}
-}
-
-func standardizeEthString(ethString string) string {
-	pieces := strings.Split(ethString, ethSizeSeparator)
-	fractionString := "0"
-	if len(pieces) > 1 {
-		fractionString = pieces[1]
-		fractionLength := len(fractionString)
-		if fractionLength > ethIntFractionLength {
-			fractionString = fractionString[0:ethIntFractionLength]
-		}
-	}
-	fractionLength := utf8.RuneCountInString(fractionString)
-	var fractionBuffer bytes.Buffer
-	fractionBuffer.WriteString(fractionString)
-	for i := fractionLength; i < ethStringFractionLength; i++ {
-		fractionBuffer.WriteString("0")
-	}
-	var standardizedBuffer bytes.Buffer
-	standardizedBuffer.WriteString(pieces[0])
-	standardizedBuffer.WriteString(ethSizeSeparator)
-	standardizedBuffer.WriteString(fractionBuffer.String())
-	return standardizedBuffer.String()
+	return newEtherFromAmount(NewAmountFromInt(CurrencyETH, ethInt))
 }
 
-func ethStringToInt(ethString string) (int64, error) {
-	if len(ethString) < 1 {
-		return 0, ConversionError{message: "Empty string passed for ether conversion [" + ethString + "]"}
-	}
-	pieces := strings.Split(ethString, ethSizeSeparator)
-	fraction, err := convertEthFractionStringToInt(pieces[1])
-	if err != nil {
-		return 0, ConversionError{message: "Error converting fraction ether [" + pieces[1] + "] for string [" + ethString + "] -- [" + err.Error() + "]"}
-	}
-	wholeCoins, err := convertWholeEthToInt(pieces)
-	if err != nil {
-		return 0, ConversionError{message: "Error converting whole ether [" + pieces[0] + "] for string [" + ethString + "] -- [" + err.Error() + "]"}
-	}
-	return wholeCoins + fraction, nil
+// NewEtherFromWei create a new ether from a wei value
+func NewEtherFromWei(wei *big.Int) Ether {
+	return newEtherFromAmount(NewAmount(CurrencyETH, wei))
 }
 
-// add padding to get to ethFractionLength decimals then convert to int64
-func convertEthFractionStringToInt(fractionString string) (int64, error) {
-	fraction, err := strconv.Atoi(fractionString)
-	if err != nil {
-		return 0, err
-	}
-	return int64(fraction), nil
+// NewEtherFromGwei create a new ether from a gwei value
+func NewEtherFromGwei(gwei *big.Int) Ether {
+	return NewEtherFromWei(new(big.Int).Mul(gwei, weiPerGwei))
 }
 
-func convertWholeEthToInt(pieces []string) (int64, error) {
-	if pieces[0] == "" {
-		return 0, nil
-	}
-	wholeCoins, err := strconv.Atoi(pieces[0])
-	if err != nil {
-		return 0, err
+// NewEtherFromAmount create a new ether from an amount denominated in ETH
+func NewEtherFromAmount(amount Amount) (Ether, error) {
+	if err := checkCurrencyDescriptor("ether conversion", CurrencyETH, amount.Currency()); err != nil {
+		return nil, err
 	}
-	return int64(wholeCoins) * ethCoinMultiplier, nil
+	return newEtherFromAmount(amount), nil
 }
 
-func ethIntToString(ethInt int64) string {
-	wholeEth := ethInt / int64(ethCoinMultiplier)
-	fractionEth := ethInt % int64(ethCoinMultiplier)
-	fractionString := strconv.FormatInt(fractionEth, 10)
-	fractionLength := utf8.RuneCountInString(fractionString)
-	var buffer bytes.Buffer
-	for i := fractionLength; i < ethStringFractionLength; i++ {
-		buffer.WriteString("0")
-	}
-	ethString := strconv.FormatInt(wholeEth, 10) + ethSizeSeparator + buffer.String() + fractionString
-	return ethString
+func newEtherFromAmount(amount Amount) Ether {
+	return etherStruct{stringValue: amount.GetStringValue(), amount: amount}
 }
@@ -0,0 +1,86 @@
+package assets
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestEtherWeiPrecision(t *testing.T) {
+	eth, err := NewEtherFromString("1234.567890123456789012")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	expectedWei, _ := new(big.Int).SetString("1234567890123456789012", 10)
+	if eth.Wei().Cmp(expectedWei) != 0 {
+		t.Errorf("Invalid wei value %s", eth.Wei().String())
+	}
+	if eth.GetStringValue() != "1234.567890123456789012" {
+		t.Errorf("Invalid ether string value %s", eth.GetStringValue())
+	}
+	if eth.Gwei().String() != "1234567890123" {
+		t.Errorf("Invalid gwei value %s", eth.Gwei().String())
+	}
+}
+
+func TestEtherIntValueSaturates(t *testing.T) {
+	ten, _ := NewEtherFromString("10")
+	if ten.GetIntValue() != math.MaxInt64 || ten.Neg().GetIntValue() != math.MinInt64 {
+		t.Errorf("Expected saturated wei %d %d", ten.GetIntValue(), ten.Neg().GetIntValue())
+	}
+	if small, _ := NewEtherFromString("0.000000000000001234"); small.GetIntValue() != 1234 {
+		t.Errorf("Invalid wei %d", small.GetIntValue())
+	}
+}
+
+func TestEtherFromWeiAndGwei(t *testing.T) {
+	wei, _ := new(big.Int).SetString("21000000000000", 10)
+	fromWei := NewEtherFromWei(wei)
+	fromGwei := NewEtherFromGwei(big.NewInt(21000))
+	if fromWei.Compare(fromGwei) != 0 {
+		t.Errorf("Expected %s to equal %s", fromWei.GetStringValue(), fromGwei.GetStringValue())
+	}
+	if fromWei.GetStringValue() != "0.000021000000000000" {
+		t.Errorf("Invalid ether string value %s", fromWei.GetStringValue())
+	}
+}
+
+func TestEtherArithmetic(t *testing.T) {
+	one, _ := NewEtherFromString("1.000000000000000001")
+	two, _ := NewEtherFromString("2")
+	sum := one.Add(two)
+	if sum.GetStringValue() != "3.000000000000000001" {
+		t.Errorf("Invalid sum %s", sum.GetStringValue())
+	}
+	difference := one.Subtract(two)
+	if difference.GetStringValue() != "-0.999999999999999999" {
+		t.Errorf("Invalid difference %s", difference.GetStringValue())
+	}
+	product := two.Multiply(150, 2)
+	if product.GetStringValue() != "3.000000000000000000" {
+		t.Errorf("Invalid product %s", product.GetStringValue())
+	}
+	price, _ := NewUSDFromString("3000.00")
+	whale, _ := NewEtherFromString("100000")
+	cost := whale.GetCost(price)
+	if cost.GetStringValue() != "300000000.00" {
+		t.Errorf("Invalid cost %s", cost.GetStringValue())
+	}
+}
+
+func TestEtherRoundingModes(t *testing.T) {
+	truncated, _ := NewEtherFromString("0.0000000000000000019")
+	if truncated.Wei().Int64() != 1 {
+		t.Errorf("Invalid default truncation %s", truncated.Wei().String())
+	}
+	rounded, _ := NewEtherFromStringRounded("0.0000000000000000019", RoundHalfUp)
+	if rounded.Wei().Int64() != 2 {
+		t.Errorf("Invalid half up rounding %s", rounded.Wei().String())
+	}
+	price, _ := NewUSDFromString("0.99")
+	half, _ := NewEtherFromString("0.5")
+	if half.GetCostRounded(price, RoundCeiling).GetIntValue() != 50 {
+		t.Errorf("Invalid cost rounding %s", half.GetCostRounded(price, RoundCeiling).GetStringValue())
+	}
+}
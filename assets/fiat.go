@@ -0,0 +1,176 @@
+package assets
+
+import "math/big"
+
+// fiat currencies with ISO 4217 minor units that NewFiat accepts
+var fiatCurrencies = map[string]Currency{
+	CurrencyUSD.Code: CurrencyUSD,
+	CurrencyEUR.Code: CurrencyEUR,
+	CurrencyGBP.Code: CurrencyGBP,
+	CurrencyJPY.Code: CurrencyJPY,
+	CurrencyKWD.Code: CurrencyKWD,
+	CurrencyCHF.Code: CurrencyCHF,
+	CurrencyCAD.Code: CurrencyCAD,
+}
+
+// LookupFiatCurrency find a supported fiat currency by ISO 4217 code
+func LookupFiatCurrency(code string) (Currency, bool) {
+	currency, ok := fiatCurrencies[code]
+	return currency, ok
+}
+
+// NewFiatFromString create fiat from string, rounding fractions of a minor unit half up
+func NewFiatFromString(currency Currency, fiatString string) (Fiat, error) {
+	return NewFiatFromStringRounded(currency, fiatString, RoundHalfUp)
+}
+
+// NewFiatFromStringRounded create fiat from string, rounding fractions of a minor unit with the rounding mode
+func NewFiatFromStringRounded(currency Currency, fiatString string, mode RoundingMode) (Fiat, error) {
+	if err := checkFiatCurrency(currency); err != nil {
+		return nil, err
+	}
+	amount, err := parseFiatString(currency, fiatString, mode, "fiat conversion")
+	if err != nil {
+		return nil, err
+	}
+	return newFiatFromAmount(amount), nil
+}
+
+// NewFiatFromInt create fiat from an int number of minor units
+func NewFiatFromInt(currency Currency, minorUnits int64) (Fiat, error) {
+	if err := checkFiatCurrency(currency); err != nil {
+		return nil, err
+	}
+	return newFiatFromAmount(NewAmountFromInt(currency, minorUnits)), nil
+}
+
+// NewFiatFromAmount create fiat from an amount denominated in a fiat currency
+func NewFiatFromAmount(amount Amount) (Fiat, error) {
+	if err := checkFiatCurrency(amount.Currency()); err != nil {
+		return nil, err
+	}
+	return newFiatFromAmountChecked(amount, "fiat conversion")
+}
+
+// NewFiatFromUSD create fiat from usd
+func NewFiatFromUSD(usd USD) Fiat {
+	return newFiatFromAmount(usd.Amount())
+}
+
+func newFiatFromAmount(amount Amount) Fiat {
+	amount = saturateInt64(amount)
+	return fiatStruct{stringValue: amount.GetStringValue(), amount: amount}
+}
+
+func newFiatFromAmountChecked(amount Amount, operation string) (Fiat, error) {
+	if _, err := bigToInt64Checked(amount.minorUnits(), operation); err != nil {
+		return nil, err
+	}
+	return newFiatFromAmount(amount), nil
+}
+
+func checkFiatCurrency(currency Currency) error {
+	fiatCurrency, ok := fiatCurrencies[currency.Code]
+	if !ok {
+		return CurrencyError{message: "Currency [" + currency.Code + "] is not a supported fiat currency"}
+	}
+	return checkCurrencyDescriptor("fiat conversion", fiatCurrency, currency)
+}
+
+// parse a fiat string shared by USD and Fiat, the amount must fit in an int64
+func parseFiatString(currency Currency, fiatString string, mode RoundingMode, operation string) (Amount, error) {
+	amount, err := ParseAmountRounded(currency, standardizeAmountString(fiatString, currency.Decimals), mode)
+	if err != nil {
+		return Amount{}, err
+	}
+	if _, err := bigToInt64Checked(amount.minorUnits(), operation); err != nil {
+		return Amount{}, err
+	}
+	return amount, nil
+}
+
+func (fiat fiatStruct) GetStringValue() string {
+	return fiat.stringValue
+}
+
+func (fiat fiatStruct) GetPrettyStringValue() string {
+	return fiat.amount.GetPrettyStringValue()
+}
+
+func (fiat fiatStruct) GetIntValue() int64 {
+	return fiat.amount.GetIntValue()
+}
+
+// Currency the currency the fiat is denominated in
+func (fiat fiatStruct) Currency() Currency {
+	return fiat.amount.Currency()
+}
+
+func (fiat fiatStruct) GetFractionLength() int64 {
+	return fiat.amount.GetFractionLength()
+}
+
+func (fiat fiatStruct) Amount() Amount {
+	return fiat.amount
+}
+
+func (fiat fiatStruct) bigIntValue() *big.Int {
+	return fiat.amount.bigIntValue()
+}
+
+// Add add fiat of the same currency, returning an OverflowError if the sum does not fit in an int64
+func (fiat fiatStruct) Add(fiatToAdd Fiat) (Fiat, error) {
+	sum, err := fiat.amount.Add(fiatToAdd.Amount())
+	if err != nil {
+		return nil, err
+	}
+	return newFiatFromAmountChecked(sum, "fiat add")
+}
+
+// Subtract subtract fiat of the same currency, returning an OverflowError if the difference does not fit in an int64
+func (fiat fiatStruct) Subtract(fiatToSubtract Fiat) (Fiat, error) {
+	difference, err := fiat.amount.Subtract(fiatToSubtract.Amount())
+	if err != nil {
+		return nil, err
+	}
+	return newFiatFromAmountChecked(difference, "fiat subtract")
+}
+
+func (fiat fiatStruct) Multiply(value int64, fractionLength int64) Fiat {
+	return fiat.MultiplyRounded(value, fractionLength, RoundHalfUp)
+}
+
+// MultiplyRounded multiply, rounding fractions of a minor unit with the rounding mode
+func (fiat fiatStruct) MultiplyRounded(value int64, fractionLength int64, mode RoundingMode) Fiat {
+	return newFiatFromAmount(fiat.amount.MultiplyRounded(value, fractionLength, mode))
+}
+
+// Compare compare fiat of the same currency ascending
+func (fiat fiatStruct) Compare(other Fiat) (int, error) {
+	return fiat.amount.Compare(other.Amount())
+}
+
+// Abs the fiat without its sign
+func (fiat fiatStruct) Abs() Fiat {
+	return newFiatFromAmount(fiat.amount.Abs())
+}
+
+// Neg the fiat with its sign flipped
+func (fiat fiatStruct) Neg() Fiat {
+	return newFiatFromAmount(fiat.amount.Neg())
+}
+
+// Sign -1, 0 or 1 for negative, zero and positive fiat
+func (fiat fiatStruct) Sign() int {
+	return fiat.amount.Sign()
+}
+
+// IsZero true when the fiat is zero
+func (fiat fiatStruct) IsZero() bool {
+	return fiat.amount.IsZero()
+}
+
+// IsNegative true when the fiat is less than zero
+func (fiat fiatStruct) IsNegative() bool {
+	return fiat.amount.IsNegative()
+}
@@ -0,0 +1,125 @@
+package assets
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestFiatMinorUnits(t *testing.T) {
+	tests := []struct {
+		currency Currency
+		input    string
+		intValue int64
+		pretty   string
+	}{
+		{CurrencyEUR, "12.5", 1250, "12.50"},
+		{CurrencyGBP, "0.015", 2, "0.02"},
+		{CurrencyJPY, "1500", 1500, "1500"},
+		{CurrencyJPY, "1500.5", 1501, "1501"},
+		{CurrencyKWD, "1.2345", 1235, "1.235"},
+		{CurrencyCHF, "-0.05", -5, "-0.05"},
+		{CurrencyCAD, "99.99", 9999, "99.99"},
+		{CurrencyUSD, "1", 100, "1.00"},
+	}
+	for _, test := range tests {
+		fiat, err := NewFiatFromString(test.currency, test.input)
+		if err != nil || fiat.GetIntValue() != test.intValue || fiat.GetPrettyStringValue() != test.pretty {
+			t.Errorf("Invalid %s fiat for %s: %v %v", test.currency.Code, test.input, fiat, err)
+		}
+		if fiat.Currency() != test.currency || fiat.GetFractionLength() != test.currency.Decimals {
+			t.Errorf("Invalid currency for %s: %s", test.input, fiat.Currency().Code)
+		}
+	}
+	yen, _ := NewFiatFromStringRounded(CurrencyJPY, "1500.5", RoundHalfEven)
+	if yen.GetIntValue() != 1500 || yen.GetStringValue() != "1500" {
+		t.Errorf("Invalid rounded yen %d %s", yen.GetIntValue(), yen.GetStringValue())
+	}
+}
+
+func TestFiatArithmetic(t *testing.T) {
+	euros, _ := NewFiatFromString(CurrencyEUR, "10.00")
+	more, _ := NewFiatFromInt(CurrencyEUR, 250)
+	sum, err := euros.Add(more)
+	if err != nil || sum.GetStringValue() != "12.50" {
+		t.Errorf("Invalid sum %v %v", sum, err)
+	}
+	difference, _ := more.Subtract(euros)
+	if difference.GetIntValue() != -750 || !difference.IsNegative() || difference.Abs().GetIntValue() != 750 {
+		t.Errorf("Invalid difference %v", difference)
+	}
+	if comparison, err := euros.Compare(more); err != nil || comparison != 1 {
+		t.Errorf("Invalid comparison %d %v", comparison, err)
+	}
+	if product := euros.Multiply(3333, 4); product.GetIntValue() != 333 {
+		t.Errorf("Invalid product %d", product.GetIntValue())
+	}
+	pounds, _ := NewFiatFromInt(CurrencyGBP, 100)
+	if _, err := euros.Add(pounds); !errors.As(err, &CurrencyError{}) {
+		t.Errorf("Expected currency error adding pounds to euros, got %v", err)
+	}
+	if _, err := euros.Compare(pounds); err == nil {
+		t.Error("Expected error comparing pounds to euros")
+	}
+	large, _ := NewFiatFromInt(CurrencyEUR, math.MaxInt64)
+	if _, err := large.Add(more); !errors.As(err, &OverflowError{}) {
+		t.Errorf("Expected overflow error, got %v", err)
+	}
+}
+
+func TestFiatConstruction(t *testing.T) {
+	if _, err := NewFiatFromString(CurrencyBTC, "1"); err == nil {
+		t.Error("Expected error creating fiat in bitcoin")
+	}
+	if _, err := NewFiatFromAmount(ZeroAmount(CurrencyETH)); err == nil {
+		t.Error("Expected error creating fiat from ether")
+	}
+	amount, _ := ParseAmount(CurrencyKWD, "3.141")
+	dinar, err := NewFiatFromAmount(amount)
+	if err != nil || dinar.GetStringValue() != "3.141" {
+		t.Errorf("Invalid dinar %v %v", dinar, err)
+	}
+	usd, _ := NewUSDFromString("4.20")
+	if fiat := NewFiatFromUSD(usd); fiat.Currency() != CurrencyUSD || fiat.GetIntValue() != 420 {
+		t.Errorf("Invalid fiat from usd %v", fiat)
+	}
+	if currency, ok := LookupFiatCurrency("JPY"); !ok || currency.Decimals != 0 {
+		t.Errorf("Invalid yen lookup %v %v", currency, ok)
+	}
+	if _, ok := LookupFiatCurrency("BTC"); ok {
+		t.Error("Expected bitcoin not to be a fiat currency")
+	}
+	if currency, ok := LookupCurrency("CHF"); !ok || currency != CurrencyCHF {
+		t.Errorf("Expected francs to be registered %v", currency)
+	}
+}
+
+func TestFiatFormat(t *testing.T) {
+	yen, _ := NewFiatFromInt(CurrencyJPY, 1234567)
+	if formatted := LocaleEnUS.Format(yen.Amount()); formatted != "¥1,234,567" {
+		t.Errorf("Invalid yen format %s", formatted)
+	}
+	francs, _ := NewFiatFromString(CurrencyCHF, "1234.5")
+	if formatted := LocaleDeCH.Format(francs.Amount()); formatted != "CHF 1’234.50" {
+		t.Errorf("Invalid franc format %s", formatted)
+	}
+	if formatted := fmt.Sprintf("%#v", francs); formatted != "1234.50 CHF" {
+		t.Errorf("Invalid fmt output %s", formatted)
+	}
+}
+
+func TestZeroDecimalFiatString(t *testing.T) {
+	if standardized := standardizeAmountString("1500", CurrencyJPY.Decimals); standardized != "1500" {
+		t.Errorf("Invalid standardized yen %s", standardized)
+	}
+	yen, err := NewFiatFromString(CurrencyJPY, "1500")
+	fromInt, _ := NewFiatFromInt(CurrencyJPY, 1500)
+	if err != nil || yen.GetStringValue() != "1500" || yen.GetStringValue() != fromInt.GetStringValue() {
+		t.Errorf("Invalid yen %v %v", yen, err)
+	}
+	parsed, err := NewFiatFromString(CurrencyJPY, yen.GetStringValue())
+	if comparison, _ := parsed.Compare(yen); err != nil || comparison != 0 || parsed.GetStringValue() != "1500" {
+		t.Errorf("Invalid round trip yen %v %v", parsed, err)
+	}
+}
@@ -0,0 +1,120 @@
+package assets
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// String implements fmt.Stringer
+func (amount Amount) String() string {
+	return amount.GetStringValue()
+}
+
+// Format implements fmt.Formatter, see formatAmount
+func (amount Amount) Format(state fmt.State, verb rune) {
+	formatAmount(state, verb, amount, amount.GetStringValue())
+}
+
+func (bitcoin bitcoinStruct) String() string {
+	return bitcoin.stringValue
+}
+
+func (bitcoin bitcoinStruct) Format(state fmt.State, verb rune) {
+	formatAmount(state, verb, bitcoin.amount, bitcoin.stringValue)
+}
+
+func (ether etherStruct) String() string {
+	return ether.stringValue
+}
+
+func (ether etherStruct) Format(state fmt.State, verb rune) {
+	formatAmount(state, verb, ether.amount, ether.stringValue)
+}
+
+func (usd usdStruct) String() string {
+	return usd.stringValue
+}
+
+func (usd usdStruct) Format(state fmt.State, verb rune) {
+	formatAmount(state, verb, usd.amount, usd.stringValue)
+}
+
+func (fiat fiatStruct) String() string {
+	return fiat.stringValue
+}
+
+func (fiat fiatStruct) Format(state fmt.State, verb rune) {
+	formatAmount(state, verb, fiat.amount, fiat.stringValue)
+}
+
+func (token tokenStruct) String() string {
+	return token.stringValue
+}
+
+func (token tokenStruct) Format(state fmt.State, verb rune) {
+	formatAmount(state, verb, token.amount, token.stringValue)
+}
+
+// formatAmount print an amount for the fmt package
+//
+//	%s %v  the string value, or rounded half up to the precision when one is given
+//	%#v    the string value followed by the currency code
+//	%f %F  rounded half up to the precision, the currency's display decimals by default
+//	%d     the number of minor units
+//	%q     the quoted string value
+//
+// the + flag always prints a sign, width pads with spaces, left aligned with the - flag
+// and zero padded after the sign with the 0 flag for %f and %d
+func formatAmount(state fmt.State, verb rune, amount Amount, stringValue string) {
+	precision, hasPrecision := state.Precision()
+	var text string
+	switch verb {
+	case 's', 'v':
+		text = stringValue
+		if hasPrecision {
+			text = formatMinorUnits(amount.minorUnits(), amount.currency.Decimals, int64(precision))
+		}
+		if verb == 'v' && state.Flag('#') {
+			text += " " + amount.currency.Code
+		}
+	case 'f', 'F':
+		if !hasPrecision {
+			precision = int(amount.currency.DisplayDecimals)
+		}
+		text = formatMinorUnits(amount.minorUnits(), amount.currency.Decimals, int64(precision))
+	case 'd':
+		text = amount.minorUnits().String()
+	case 'q':
+		text = strconv.Quote(stringValue)
+	default:
+		fmt.Fprintf(state, "%%!%c(%s=%s)", verb, amount.currency.Code, stringValue)
+		return
+	}
+	if state.Flag('+') && verb != 'q' && !strings.HasPrefix(text, "-") {
+		text = "+" + text
+	}
+	if width, ok := state.Width(); ok {
+		text = padFormatted(text, width, state.Flag('-'), state.Flag('0') && (verb == 'f' || verb == 'F' || verb == 'd'))
+	}
+	fmt.Fprint(state, text)
+}
+
+func padFormatted(text string, width int, leftAlign bool, zeroPad bool) string {
+	padding := width - utf8.RuneCountInString(text)
+	if padding <= 0 {
+		return text
+	}
+	if leftAlign {
+		return text + strings.Repeat(" ", padding)
+	}
+	if zeroPad {
+		sign := ""
+		if strings.HasPrefix(text, "-") || strings.HasPrefix(text, "+") {
+			sign, text = text[:1], text[1:]
+		}
+		return sign + strings.Repeat("0", padding) + text
+	}
+	return strings.Repeat(" ", padding) + text
+}
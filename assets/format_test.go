@@ -0,0 +1,43 @@
+package assets
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFmtFormatting(t *testing.T) {
+	btc, _ := NewBitcoinFromString("1.23456789")
+	usd := NewUSDFromInt(-4250)
+	eth, _ := NewEtherFromString("0.000000000000000001")
+	cases := []struct {
+		actual   string
+		expected string
+	}{
+		{fmt.Sprintf("%v", btc), "1.23456789"},
+		{fmt.Sprintf("%s", btc), "1.23456789"},
+		{fmt.Sprint(btc), "1.23456789"},
+		{fmt.Sprintf("%+s", btc), "+1.23456789"},
+		{fmt.Sprintf("%+v", usd), "-42.50"},
+		{fmt.Sprintf("%#v", btc), "1.23456789 BTC"},
+		{fmt.Sprintf("%.2f", btc), "1.23"},
+		{fmt.Sprintf("%.4f", btc), "1.2346"},
+		{fmt.Sprintf("%.0f", btc), "1"},
+		{fmt.Sprintf("%f", usd), "-42.50"},
+		{fmt.Sprintf("%d", btc), "123456789"},
+		{fmt.Sprintf("%d", eth), "1"},
+		{fmt.Sprintf("%.3s", eth), "0.000"},
+		{fmt.Sprintf("%q", usd), `"-42.50"`},
+		{fmt.Sprintf("%10.2f|", usd), "    -42.50|"},
+		{fmt.Sprintf("%-10.2f|", usd), "-42.50    |"},
+		{fmt.Sprintf("%010.2f", usd), "-000042.50"},
+		{fmt.Sprintf("%+08.1f", btc), "+00001.2"},
+		{fmt.Sprintf("%x", btc), "%!x(BTC=1.23456789)"},
+		{fmt.Sprintf("%v", btc.Amount()), "1.23456789"},
+		{fmt.Sprintf("%v", []Bitcoin{btc, NewBitcoinFromInt(1)}), "[1.23456789 0.00000001]"},
+	}
+	for _, c := range cases {
+		if c.actual != c.expected {
+			t.Errorf("Expected %s but got %s", c.expected, c.actual)
+		}
+	}
+}
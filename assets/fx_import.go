@@ -0,0 +1,221 @@
+package assets
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// date layouts used by rate files, ISO dates in the ECB historical files and the generic CSV,
+// spelled out dates in the ECB daily CSV
+var rateDateLayouts = []string{time.RFC3339, "2006-01-02", "02 January 2006"}
+
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+// ParseECBXML read the ECB euro foreign exchange reference rates from the eurofxref daily or historical XML,
+// each rate is a price of one euro quoted at midnight UTC on its day. Currencies that are not registered
+// are skipped as their minor units are unknown
+func ParseECBXML(reader io.Reader) ([]RateQuote, error) {
+	var envelope ecbEnvelope
+	if err := xml.NewDecoder(reader).Decode(&envelope); err != nil {
+		return nil, err
+	}
+	var quotes []RateQuote
+	for _, day := range envelope.Days {
+		at, err := parseRateDate(day.Time)
+		if err != nil {
+			return nil, err
+		}
+		for _, rate := range day.Rates {
+			quote, ok, err := newECBQuote(rate.Currency, rate.Rate, at)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				quotes = append(quotes, quote)
+			}
+		}
+	}
+	return quotes, nil
+}
+
+// ParseECBCSV read the ECB euro foreign exchange reference rates from the eurofxref daily or historical CSV,
+// a Date column followed by a column per currency. Rates of N/A and unregistered currencies are skipped
+func ParseECBCSV(reader io.Reader) ([]RateQuote, error) {
+	records, err := readRateRecords(reader)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 || !strings.EqualFold(records[0][0], "Date") {
+		return nil, ConversionError{message: "ECB rate file has no Date header"}
+	}
+	header := records[0]
+	var quotes []RateQuote
+	for _, record := range records[1:] {
+		at, err := parseRateDate(record[0])
+		if err != nil {
+			return nil, err
+		}
+		for i := 1; i < len(record) && i < len(header); i++ {
+			quote, ok, err := newECBQuote(header[i], record[i], at)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				quotes = append(quotes, quote)
+			}
+		}
+	}
+	return quotes, nil
+}
+
+// ParseRatesCSV read quotes from a CSV with a header naming base, quote, rate and time columns in any order.
+// Currencies are looked up by code and must be registered, times are RFC 3339 or ISO dates
+func ParseRatesCSV(reader io.Reader) ([]RateQuote, error) {
+	records, err := readRateRecords(reader)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, ConversionError{message: "Rate file has no header"}
+	}
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.ToLower(name)] = i
+	}
+	for _, name := range []string{"base", "quote", "rate", "time"} {
+		if _, ok := columns[name]; !ok {
+			return nil, ConversionError{message: "Rate file is missing the [" + name + "] column"}
+		}
+	}
+	quotes := make([]RateQuote, 0, len(records)-1)
+	for _, record := range records[1:] {
+		field := func(name string) string {
+			if columns[name] < len(record) {
+				return record[columns[name]]
+			}
+			return ""
+		}
+		base, err := lookupRateCurrency(field("base"))
+		if err != nil {
+			return nil, err
+		}
+		quote, err := lookupRateCurrency(field("quote"))
+		if err != nil {
+			return nil, err
+		}
+		price, err := ParsePrice(base, quote, field("rate"))
+		if err != nil {
+			return nil, err
+		}
+		at, err := parseRateDate(field("time"))
+		if err != nil {
+			return nil, err
+		}
+		quotes = append(quotes, RateQuote{Price: price, Time: at})
+	}
+	return quotes, nil
+}
+
+// ImportECBFile add the rates from an ECB eurofxref file to the table, reading XML or CSV by file extension
+func (table *RateTable) ImportECBFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	parse := ParseECBCSV
+	if strings.HasSuffix(strings.ToLower(path), ".xml") {
+		parse = ParseECBXML
+	}
+	quotes, err := parse(file)
+	if err != nil {
+		return err
+	}
+	table.Import(quotes)
+	return nil
+}
+
+// ImportRatesCSVFile add the rates from a generic rate CSV to the table, see ParseRatesCSV
+func (table *RateTable) ImportRatesCSVFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	quotes, err := ParseRatesCSV(file)
+	if err != nil {
+		return err
+	}
+	table.Import(quotes)
+	return nil
+}
+
+// Import set each quote, a historical file leaves the newest quote for each pair
+func (table *RateTable) Import(quotes []RateQuote) {
+	for _, quote := range quotes {
+		table.Set(quote.Price, quote.Time)
+	}
+}
+
+// ConvertToFiat convert an amount to a fiat currency at now, rounding half up to the fiat's minor unit
+func (table *RateTable) ConvertToFiat(amount Amount, target Currency, now time.Time) (Fiat, error) {
+	if err := checkFiatCurrency(target); err != nil {
+		return nil, err
+	}
+	conversion, err := table.ConvertAt(amount, target, now, RoundHalfUp)
+	if err != nil {
+		return nil, err
+	}
+	return newFiatFromAmountChecked(conversion.Amount, "fiat conversion")
+}
+
+func newECBQuote(code, rate string, at time.Time) (RateQuote, bool, error) {
+	code = strings.TrimSpace(code)
+	rate = strings.TrimSpace(rate)
+	currency, ok := LookupCurrency(code)
+	if !ok || code == "" || rate == "" || rate == "N/A" {
+		return RateQuote{}, false, nil
+	}
+	price, err := ParsePrice(CurrencyEUR, currency, rate)
+	if err != nil {
+		return RateQuote{}, false, err
+	}
+	return RateQuote{Price: price, Time: at}, true, nil
+}
+
+func readRateRecords(reader io.Reader) ([][]string, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+	csvReader.FieldsPerRecord = -1
+	return csvReader.ReadAll()
+}
+
+func lookupRateCurrency(code string) (Currency, error) {
+	currency, ok := LookupCurrency(strings.TrimSpace(code))
+	if !ok {
+		return Currency{}, CurrencyError{message: "Unknown currency [" + code + "] in rate file"}
+	}
+	return currency, nil
+}
+
+func parseRateDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range rateDateLayouts {
+		if at, err := time.Parse(layout, value); err == nil {
+			return at, nil
+		}
+	}
+	return time.Time{}, ConversionError{message: "Invalid rate date [" + value + "]"}
+}
@@ -0,0 +1,118 @@
+package assets
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testECBDate = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func TestParseECBDaily(t *testing.T) {
+	for _, path := range []string{"testdata/eurofxref-daily.xml", "testdata/eurofxref.csv"} {
+		file, err := os.Open(path)
+		if err != nil {
+			t.Fatalf("Error opening %s %v", path, err)
+		}
+		parse := ParseECBCSV
+		if strings.HasSuffix(path, ".xml") {
+			parse = ParseECBXML
+		}
+		quotes, err := parse(file)
+		file.Close()
+		if err != nil {
+			t.Fatalf("Error parsing %s %v", path, err)
+		}
+		// BGN is not a registered currency
+		if len(quotes) != 5 {
+			t.Fatalf("Invalid number of quotes in %s %d", path, len(quotes))
+		}
+		if quotes[0].Price.String() != "EUR/USD 1.0838" || !quotes[0].Time.Equal(testECBDate) {
+			t.Errorf("Invalid first quote in %s %s at %v", path, quotes[0].Price, quotes[0].Time)
+		}
+		if quotes[1].Price.String() != "EUR/JPY 162.51" {
+			t.Errorf("Invalid yen quote in %s %s", path, quotes[1].Price)
+		}
+	}
+}
+
+func TestParseECBHistorical(t *testing.T) {
+	xmlFile, _ := os.Open("testdata/eurofxref-hist.xml")
+	defer xmlFile.Close()
+	quotes, err := ParseECBXML(xmlFile)
+	if err != nil || len(quotes) != 6 {
+		t.Fatalf("Invalid historical quotes %d %v", len(quotes), err)
+	}
+	if quotes[5].Price.String() != "EUR/GBP 0.85503" || !quotes[5].Time.Equal(time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Invalid last quote %s at %v", quotes[5].Price, quotes[5].Time)
+	}
+	csvFile, _ := os.Open("testdata/eurofxref-hist.csv")
+	defer csvFile.Close()
+	csvQuotes, err := ParseECBCSV(csvFile)
+	// CYP is unregistered and N/A in recent rows
+	if err != nil || len(csvQuotes) != 9 {
+		t.Fatalf("Invalid historical csv quotes %d %v", len(csvQuotes), err)
+	}
+	if csvQuotes[8].Price.String() != "EUR/GBP 0.7111" {
+		t.Errorf("Invalid last csv quote %s", csvQuotes[8].Price)
+	}
+}
+
+func TestImportECBKeepsNewest(t *testing.T) {
+	table := NewRateTable(0, StaleReject)
+	if err := table.ImportECBFile("testdata/eurofxref-hist.csv"); err != nil {
+		t.Fatalf("Error importing %v", err)
+	}
+	if quote, ok := table.Lookup(CurrencyEUR, CurrencyUSD); !ok || quote.Price.String() != "EUR/USD 1.0838" {
+		t.Errorf("Expected newest quote, got %s", quote.Price)
+	}
+}
+
+func TestConvertUSDToFiat(t *testing.T) {
+	table := NewRateTable(48*time.Hour, StaleReject)
+	if err := table.ImportECBFile("testdata/eurofxref-daily.xml"); err != nil {
+		t.Fatalf("Error importing %v", err)
+	}
+	usd, _ := NewUSDFromString("1083.80")
+	now := testECBDate.Add(18 * time.Hour)
+	pounds, err := table.ConvertToFiat(usd.Amount(), CurrencyGBP, now)
+	if err != nil || pounds.GetStringValue() != "856.43" || pounds.Currency() != CurrencyGBP {
+		t.Errorf("Invalid pounds %v %v", pounds, err)
+	}
+	yen, _ := table.ConvertToFiat(usd.Amount(), CurrencyJPY, now)
+	if yen.GetStringValue() != "162510" {
+		t.Errorf("Invalid yen %s", yen.GetStringValue())
+	}
+	if _, err := table.ConvertToFiat(usd.Amount(), CurrencyGBP, now.Add(72*time.Hour)); err == nil {
+		t.Error("Expected stale quote error")
+	}
+	if _, err := table.ConvertToFiat(usd.Amount(), CurrencyBTC, now); err == nil {
+		t.Error("Expected error converting to bitcoin as fiat")
+	}
+}
+
+func TestParseRatesCSV(t *testing.T) {
+	table := NewRateTable(0, StaleReject)
+	if err := table.ImportRatesCSVFile("testdata/rates.csv"); err != nil {
+		t.Fatalf("Error importing %v", err)
+	}
+	btc, _ := ParseAmount(CurrencyBTC, "0.1")
+	dinar, err := table.ConvertToFiat(btc, CurrencyKWD, testECBDate)
+	if err != nil || dinar.GetStringValue() != "1842.000" {
+		t.Errorf("Invalid dinar %v %v", dinar, err)
+	}
+	if quote, _ := table.Lookup(CurrencyUSD, CurrencyKWD); !quote.Time.Equal(testECBDate) {
+		t.Errorf("Invalid date only time %v", quote.Time)
+	}
+	for _, input := range []string{
+		"base,quote,rate\nBTC,USD,1\n",
+		"base,quote,rate,time\nXXX,USD,1,2024-03-01\n",
+		"base,quote,rate,time\nBTC,USD,abc,2024-03-01\n",
+		"base,quote,rate,time\nBTC,USD,1,yesterday\n",
+	} {
+		if _, err := ParseRatesCSV(strings.NewReader(input)); err == nil {
+			t.Errorf("Expected error parsing %q", input)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package assets
+
+import "math/big"
+
+// GasPrice price of a unit of gas in wei
+type GasPrice struct {
+	weiPerGas *big.Int
+}
+
+// NewGasPrice create a gas price from a number of wei per gas
+func NewGasPrice(weiPerGas *big.Int) GasPrice {
+	return GasPrice{weiPerGas: new(big.Int).Set(weiPerGas)}
+}
+
+// NewGasPriceFromGwei create a gas price from a number of gwei per gas
+func NewGasPriceFromGwei(gweiPerGas int64) GasPrice {
+	return GasPrice{weiPerGas: new(big.Int).Mul(big.NewInt(gweiPerGas), weiPerGwei)}
+}
+
+// ParseGasPrice create a gas price from a string with an ether denomination, 21 gwei or 1.5 gwei,
+// truncating fractions of a wei
+func ParseGasPrice(denominated string) (GasPrice, error) {
+	weiPerGas, err := parseScaled(CurrencyETH, denominated, ethIntFractionLength, RoundTruncate)
+	if err != nil {
+		return GasPrice{}, err
+	}
+	return GasPrice{weiPerGas: weiPerGas}, nil
+}
+
+// Wei the price of a unit of gas in wei
+func (gasPrice GasPrice) Wei() *big.Int {
+	return new(big.Int).Set(gasPrice.value())
+}
+
+// Fee the ether paid for gasLimit units of gas at the price
+func (gasPrice GasPrice) Fee(gasLimit uint64) Ether {
+	return NewEtherFromWei(new(big.Int).Mul(gasPrice.value(), new(big.Int).SetUint64(gasLimit)))
+}
+
+// Compare sort by price ascending
+func (gasPrice GasPrice) Compare(other GasPrice) int {
+	return gasPrice.value().Cmp(other.value())
+}
+
+// Format the price per gas in an ether denomination, dropping trailing fraction zeros
+func (gasPrice GasPrice) Format(denomination Denomination) (string, error) {
+	return denomination.Format(NewAmount(CurrencyETH, gasPrice.value()))
+}
+
+// String the price per gas in gwei, 21 gwei
+func (gasPrice GasPrice) String() string {
+	formatted, _ := gasPrice.Format(DenominationGwei)
+	return formatted
+}
+
+func (gasPrice GasPrice) value() *big.Int {
+	if gasPrice.weiPerGas == nil {
+		return new(big.Int)
+	}
+	return gasPrice.weiPerGas
+}
@@ -0,0 +1,161 @@
+package assets
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strings"
+)
+
+// Encoding how an amount is written to JSON and databases
+type Encoding int
+
+const (
+	// DecimalEncoding a decimal string such as "1.50000000"
+	DecimalEncoding Encoding = iota
+	// MinorUnitEncoding an integer number of minor units such as 150000000 satoshis
+	MinorUnitEncoding
+)
+
+// MarshalJSON encodes as a decimal string
+func (bitcoin bitcoinStruct) MarshalJSON() ([]byte, error) {
+	return marshalAmountJSON(bitcoin.amount, DecimalEncoding)
+}
+
+// MarshalJSON encodes as a decimal string
+func (ether etherStruct) MarshalJSON() ([]byte, error) {
+	return marshalAmountJSON(ether.amount, DecimalEncoding)
+}
+
+// MarshalJSON encodes as a decimal string
+func (usd usdStruct) MarshalJSON() ([]byte, error) {
+	return marshalAmountJSON(usd.amount, DecimalEncoding)
+}
+
+// MarshalJSON encodes as a decimal string
+func (fiat fiatStruct) MarshalJSON() ([]byte, error) {
+	return marshalAmountJSON(fiat.amount, DecimalEncoding)
+}
+
+// BitcoinValue concrete holder for a Bitcoin that can be decoded from JSON, as a
+// decimal string or number, or with MinorUnitEncoding as an integer number of satoshis
+type BitcoinValue struct {
+	Bitcoin
+	Encoding Encoding
+}
+
+// MarshalJSON encodes with the value's encoding, null when there is no Bitcoin
+func (value BitcoinValue) MarshalJSON() ([]byte, error) {
+	if value.Bitcoin == nil {
+		return []byte("null"), nil
+	}
+	return marshalAmountJSON(value.Bitcoin.Amount(), value.Encoding)
+}
+
+// UnmarshalJSON decodes a string or number, validated like NewBitcoinFromString
+func (value *BitcoinValue) UnmarshalJSON(data []byte) error {
+	amount, isNull, err := unmarshalAmountJSON(CurrencyBTC, data, value.Encoding, RoundHalfUp)
+	if err != nil || isNull {
+		return err
+	}
+	bitcoin, err := NewBitcoinFromAmount(amount)
+	if err != nil {
+		return err
+	}
+	value.Bitcoin = bitcoin
+	return nil
+}
+
+// EtherValue concrete holder for an Ether that can be decoded from JSON, as a
+// decimal string or number, or with MinorUnitEncoding as an integer number of wei
+type EtherValue struct {
+	Ether
+	Encoding Encoding
+}
+
+// MarshalJSON encodes with the value's encoding, null when there is no Ether
+func (value EtherValue) MarshalJSON() ([]byte, error) {
+	if value.Ether == nil {
+		return []byte("null"), nil
+	}
+	return marshalAmountJSON(value.Ether.Amount(), value.Encoding)
+}
+
+// UnmarshalJSON decodes a string or number, validated like NewEtherFromString
+func (value *EtherValue) UnmarshalJSON(data []byte) error {
+	amount, isNull, err := unmarshalAmountJSON(CurrencyETH, data, value.Encoding, RoundTruncate)
+	if err != nil || isNull {
+		return err
+	}
+	value.Ether = newEtherFromAmount(amount)
+	return nil
+}
+
+// USDValue concrete holder for a USD that can be decoded from JSON, as a
+// decimal string or number, or with MinorUnitEncoding as an integer number of cents
+type USDValue struct {
+	USD
+	Encoding Encoding
+}
+
+// MarshalJSON encodes with the value's encoding, null when there is no USD
+func (value USDValue) MarshalJSON() ([]byte, error) {
+	if value.USD == nil {
+		return []byte("null"), nil
+	}
+	return marshalAmountJSON(value.USD.Amount(), value.Encoding)
+}
+
+// UnmarshalJSON decodes a string or number, validated like NewUSDFromString
+func (value *USDValue) UnmarshalJSON(data []byte) error {
+	amount, isNull, err := unmarshalAmountJSON(CurrencyUSD, data, value.Encoding, RoundHalfUp)
+	if err != nil || isNull {
+		return err
+	}
+	usd, err := NewUSDFromAmount(amount)
+	if err != nil {
+		return err
+	}
+	value.USD = usd
+	return nil
+}
+
+func marshalAmountJSON(amount Amount, encoding Encoding) ([]byte, error) {
+	if encoding == MinorUnitEncoding {
+		return []byte(amount.minorUnits().String()), nil
+	}
+	return json.Marshal(amount.GetStringValue())
+}
+
+// strings and numbers are read as decimal amounts rounded with the mode the type's string
+// constructor uses, except that with MinorUnitEncoding a number is an integer number of minor units
+func unmarshalAmountJSON(currency Currency, data []byte, encoding Encoding, mode RoundingMode) (Amount, bool, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return Amount{}, true, nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var amountString string
+		if err := json.Unmarshal(data, &amountString); err != nil {
+			return Amount{}, false, err
+		}
+		amount, err := ParseAmountRounded(currency, standardizeAmountString(amountString, currency.Decimals), mode)
+		return amount, false, err
+	}
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return Amount{}, false, err
+	}
+	if encoding == MinorUnitEncoding {
+		minorUnits, ok := new(big.Int).SetString(number.String(), 10)
+		if !ok {
+			return Amount{}, false, ConversionError{message: "Invalid " + currency.Code + " minor units [" + number.String() + "]"}
+		}
+		return NewAmount(currency, minorUnits), false, nil
+	}
+	if strings.ContainsAny(number.String(), "eE") {
+		return Amount{}, false, ConversionError{message: "Exponents are not supported for " + currency.Code + " [" + number.String() + "]"}
+	}
+	amount, err := ParseAmountRounded(currency, standardizeAmountString(number.String(), currency.Decimals), mode)
+	return amount, false, err
+}
@@ -0,0 +1,100 @@
+package assets
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type testPayment struct {
+	Amount BitcoinValue `json:"amount"`
+	Fee    EtherValue   `json:"fee"`
+	Price  USDValue     `json:"price"`
+}
+
+func TestMarshalJSON(t *testing.T) {
+	btc, _ := NewBitcoinFromString("1.5")
+	usd, _ := NewUSDFromString("60000")
+	encoded, err := json.Marshal(map[string]interface{}{"btc": btc, "usd": usd})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if string(encoded) != `{"btc":"1.50000000","usd":"60000.00"}` {
+		t.Errorf("Invalid encoding %s", encoded)
+	}
+	minor, _ := json.Marshal(BitcoinValue{Bitcoin: btc, Encoding: MinorUnitEncoding})
+	if string(minor) != "150000000" {
+		t.Errorf("Invalid minor unit encoding %s", minor)
+	}
+	empty, _ := json.Marshal(testPayment{})
+	if string(empty) != `{"amount":null,"fee":null,"price":null}` {
+		t.Errorf("Invalid empty encoding %s", empty)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	var payment testPayment
+	err := json.Unmarshal([]byte(`{"amount": "0.015", "fee": 0.000000000000000021, "price": 60000.5}`), &payment)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if payment.Amount.GetStringValue() != "0.01500000" {
+		t.Errorf("Invalid amount %s", payment.Amount.GetStringValue())
+	}
+	if payment.Fee.Wei().Int64() != 21 {
+		t.Errorf("Invalid fee %s", payment.Fee.Wei().String())
+	}
+	if payment.Price.GetIntValue() != 6000050 {
+		t.Errorf("Invalid price %d", payment.Price.GetIntValue())
+	}
+	minor := testPayment{Amount: BitcoinValue{Encoding: MinorUnitEncoding}}
+	if err := json.Unmarshal([]byte(`{"amount": 150000000}`), &minor); err != nil {
+		t.Error(err)
+		return
+	}
+	if minor.Amount.GetStringValue() != "1.50000000" {
+		t.Errorf("Invalid minor unit amount %s", minor.Amount.GetStringValue())
+	}
+	roundTrip, _ := json.Marshal(minor.Amount)
+	if string(roundTrip) != "150000000" {
+		t.Errorf("Invalid round trip %s", roundTrip)
+	}
+}
+
+func TestUnmarshalJSONRoundsLikeConstructors(t *testing.T) {
+	for _, input := range []string{"0.0000000000000000019", "0.123456785"} {
+		var ether EtherValue
+		if err := json.Unmarshal([]byte(`"`+input+`"`), &ether); err != nil {
+			t.Error(err)
+			continue
+		}
+		expectedEther, _ := NewEtherFromString(input)
+		if ether.Wei().Cmp(expectedEther.Wei()) != 0 {
+			t.Errorf("Expected %s wei for %s but got %s", expectedEther.Wei(), input, ether.Wei())
+		}
+		var bitcoin BitcoinValue
+		if err := json.Unmarshal([]byte(input), &bitcoin); err != nil {
+			t.Error(err)
+			continue
+		}
+		expectedBitcoin, _ := NewBitcoinFromString(input)
+		if bitcoin.GetIntValue() != expectedBitcoin.GetIntValue() {
+			t.Errorf("Expected %d satoshis for %s but got %d", expectedBitcoin.GetIntValue(), input, bitcoin.GetIntValue())
+		}
+	}
+}
+
+func TestUnmarshalJSONErrors(t *testing.T) {
+	inputs := []string{`{"amount": "abc"}`, `{"amount": 1e8}`, `{"amount": true}`, `{"amount": "100000000000"}`, `{"amount": ""}`, `{"amount": "-."}`, `{"price": ""}`}
+	for _, input := range inputs {
+		var payment testPayment
+		if err := json.Unmarshal([]byte(input), &payment); err == nil {
+			t.Errorf("Expected error decoding %s", input)
+		}
+	}
+	var minor = BitcoinValue{Encoding: MinorUnitEncoding}
+	if err := json.Unmarshal([]byte("1.5"), &minor); err == nil {
+		t.Error("Expected error decoding fractional minor units")
+	}
+}
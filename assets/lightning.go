@@ -0,0 +1,82 @@
+package assets
+
+import "math/big"
+
+const millisatoshiFractionLength int64 = 11
+
+var millisatoshisPerSatoshi = pow10Big(millisatoshiFractionLength - btcIntFractionLength)
+
+// LightningAmount bitcoin amount held in millisatoshis as lightning payments are, converting to
+// bitcoin needs a rounding mode for any fraction of a satoshi
+type LightningAmount struct {
+	millisatoshis *big.Int
+}
+
+// NewLightningAmount create a lightning amount from a number of millisatoshis
+func NewLightningAmount(millisatoshis int64) LightningAmount {
+	return LightningAmount{millisatoshis: big.NewInt(millisatoshis)}
+}
+
+// NewLightningAmountFromBitcoin create a lightning amount from bitcoin, always exact
+func NewLightningAmountFromBitcoin(bitcoin Bitcoin) LightningAmount {
+	return LightningAmount{millisatoshis: new(big.Int).Mul(bitcoin.Amount().minorUnits(), millisatoshisPerSatoshi)}
+}
+
+// ParseLightningAmount create a lightning amount from a string with a denomination, 1500 msat or 2 sats,
+// rounding fractions of a millisatoshi half up
+func ParseLightningAmount(denominated string) (LightningAmount, error) {
+	millisatoshis, err := parseScaled(CurrencyBTC, denominated, millisatoshiFractionLength, RoundHalfUp)
+	if err != nil {
+		return LightningAmount{}, err
+	}
+	return LightningAmount{millisatoshis: millisatoshis}, nil
+}
+
+// Millisatoshis the amount as a number of millisatoshis
+func (lightning LightningAmount) Millisatoshis() *big.Int {
+	return new(big.Int).Set(lightning.value())
+}
+
+// Bitcoin the amount in bitcoin, rounding any fraction of a satoshi with the rounding mode
+func (lightning LightningAmount) Bitcoin(mode RoundingMode) Bitcoin {
+	return newBitcoinFromAmount(NewAmount(CurrencyBTC, quoRound(lightning.value(), millisatoshisPerSatoshi, mode)))
+}
+
+// IsWholeSatoshis true when the amount converts to bitcoin without rounding
+func (lightning LightningAmount) IsWholeSatoshis() bool {
+	return new(big.Int).Rem(lightning.value(), millisatoshisPerSatoshi).Sign() == 0
+}
+
+func (lightning LightningAmount) Add(amountToAdd LightningAmount) LightningAmount {
+	return LightningAmount{millisatoshis: new(big.Int).Add(lightning.value(), amountToAdd.value())}
+}
+
+func (lightning LightningAmount) Subtract(amountToSubtract LightningAmount) LightningAmount {
+	return LightningAmount{millisatoshis: new(big.Int).Sub(lightning.value(), amountToSubtract.value())}
+}
+
+// Compare sort by amount ascending
+func (lightning LightningAmount) Compare(other LightningAmount) int {
+	return lightning.value().Cmp(other.value())
+}
+
+// Format the amount in a bitcoin denomination, dropping trailing fraction zeros
+func (lightning LightningAmount) Format(denomination Denomination) (string, error) {
+	if denomination.Currency().Code != CurrencyBTC.Code {
+		return "", newCurrencyMismatchError("lightning format", CurrencyBTC, denomination.Currency())
+	}
+	return denomination.formatScaled(lightning.value(), millisatoshiFractionLength, Locale{DecimalMark: amountSeparator}), nil
+}
+
+// String the amount in millisatoshis, 1500 msat
+func (lightning LightningAmount) String() string {
+	formatted, _ := lightning.Format(DenominationMillisatoshi)
+	return formatted
+}
+
+func (lightning LightningAmount) value() *big.Int {
+	if lightning.millisatoshis == nil {
+		return new(big.Int)
+	}
+	return lightning.millisatoshis
+}
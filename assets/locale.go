@@ -0,0 +1,196 @@
+package assets
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SymbolPlacement where a locale puts the currency symbol
+type SymbolPlacement int
+
+const (
+	// SymbolBefore symbol precedes the number, $1.00
+	SymbolBefore SymbolPlacement = iota
+	// SymbolAfter symbol follows the number, 1,00 €
+	SymbolAfter
+	// SymbolNone no symbol is printed
+	SymbolNone
+)
+
+// NegativeStyle how a locale marks negative amounts
+type NegativeStyle int
+
+const (
+	// NegativeMinus leading minus sign, -$1.00
+	NegativeMinus NegativeStyle = iota
+	// NegativeParentheses accounting style parentheses, ($1.00)
+	NegativeParentheses
+)
+
+// Locale conventions for printing and reading amounts
+type Locale struct {
+	// GroupSeparator printed between groups of whole digits, empty for no grouping
+	GroupSeparator string
+	// GroupSize number of digits in each group
+	GroupSize int
+	// DecimalMark printed between the whole and fraction digits
+	DecimalMark string
+	// SymbolPlacement where the currency symbol goes
+	SymbolPlacement SymbolPlacement
+	// SymbolSpacing put a space between the symbol and the number
+	SymbolSpacing bool
+	// NegativeStyle how negative amounts are marked
+	NegativeStyle NegativeStyle
+}
+
+var (
+	// LocaleEnUS $1,234,567.89
+	LocaleEnUS = Locale{GroupSeparator: ",", GroupSize: 3, DecimalMark: ".", SymbolPlacement: SymbolBefore}
+	// LocaleEnUSAccounting ($1,234,567.89)
+	LocaleEnUSAccounting = Locale{GroupSeparator: ",", GroupSize: 3, DecimalMark: ".", SymbolPlacement: SymbolBefore, NegativeStyle: NegativeParentheses}
+	// LocaleEnGB £1,234,567.89
+	LocaleEnGB = Locale{GroupSeparator: ",", GroupSize: 3, DecimalMark: ".", SymbolPlacement: SymbolBefore}
+	// LocaleDeDE 1.234.567,89 €
+	LocaleDeDE = Locale{GroupSeparator: ".", GroupSize: 3, DecimalMark: ",", SymbolPlacement: SymbolAfter, SymbolSpacing: true}
+	// LocaleFrFR 1 234 567,89 € with narrow no-break spaces between groups
+	LocaleFrFR = Locale{GroupSeparator: " ", GroupSize: 3, DecimalMark: ",", SymbolPlacement: SymbolAfter, SymbolSpacing: true}
+	// LocaleDeCH CHF 1’234’567.89
+	LocaleDeCH = Locale{GroupSeparator: "’", GroupSize: 3, DecimalMark: ".", SymbolPlacement: SymbolBefore, SymbolSpacing: true}
+)
+
+// Format print the amount with the currency's display decimals
+func (locale Locale) Format(amount Amount) string {
+	return locale.FormatDecimals(amount, amount.Currency().DisplayDecimals)
+}
+
+// FormatDecimals print the amount rounded half up to the number of decimals
+func (locale Locale) FormatDecimals(amount Amount, decimals int64) string {
+	currency := amount.Currency()
+	plain := formatMinorUnits(amount.minorUnits(), currency.Decimals, decimals)
+	negative := strings.HasPrefix(plain, "-")
+	whole, fraction, hasFraction := strings.Cut(strings.TrimPrefix(plain, "-"), amountSeparator)
+	number := locale.groupDigits(whole)
+	if hasFraction {
+		number += locale.DecimalMark + fraction
+	}
+	number = locale.placeSymbol(number, currency.Symbol)
+	if !negative {
+		return number
+	}
+	if locale.NegativeStyle == NegativeParentheses {
+		return "(" + number + ")"
+	}
+	return "-" + number
+}
+
+// Parse read an amount printed in the locale's conventions, the symbol, grouping and
+// a leading or trailing sign are optional. The digits are then read strictly, so
+// errors about the number itself refer to it with grouping and symbol removed
+func (locale Locale) Parse(currency Currency, formatted string) (Amount, error) {
+	newError := func(kind ParseErrorKind, position int) error {
+		return ParseError{Kind: kind, Currency: currency.Code, Input: formatted, Position: position}
+	}
+	number := strings.TrimFunc(formatted, unicode.IsSpace)
+	if number == "" {
+		return Amount{}, newError(ParseErrorEmpty, 0)
+	}
+	negative := false
+	if strings.HasPrefix(number, "(") && strings.HasSuffix(number, ")") {
+		negative = true
+		number = strings.TrimFunc(number[1:len(number)-1], unicode.IsSpace)
+	}
+	number, negative = cutSign(number, negative)
+	number = trimSymbol(number, currency)
+	number, negative = cutSign(number, negative)
+	whole, fraction, hasFraction := strings.Cut(number, locale.DecimalMark)
+	whole, ok := locale.ungroupDigits(whole)
+	if !ok {
+		return Amount{}, newError(ParseErrorInvalidGrouping, strings.Index(formatted, whole))
+	}
+	normalized := whole
+	if hasFraction {
+		normalized += amountSeparator + fraction
+	}
+	if negative {
+		normalized = "-" + normalized
+	}
+	return ParseAmountStrict(currency, normalized)
+}
+
+func (locale Locale) groupDigits(whole string) string {
+	if locale.GroupSeparator == "" || locale.GroupSize < 1 || len(whole) <= locale.GroupSize {
+		return whole
+	}
+	firstGroup := len(whole) % locale.GroupSize
+	if firstGroup == 0 {
+		firstGroup = locale.GroupSize
+	}
+	var builder strings.Builder
+	builder.WriteString(whole[:firstGroup])
+	for i := firstGroup; i < len(whole); i += locale.GroupSize {
+		builder.WriteString(locale.GroupSeparator)
+		builder.WriteString(whole[i : i+locale.GroupSize])
+	}
+	return builder.String()
+}
+
+// remove group separators, every group after the first must be exactly GroupSize digits
+func (locale Locale) ungroupDigits(whole string) (string, bool) {
+	if locale.GroupSeparator == "" || !strings.Contains(whole, locale.GroupSeparator) {
+		return whole, true
+	}
+	groups := strings.Split(whole, locale.GroupSeparator)
+	if len(groups[0]) < 1 || len(groups[0]) > locale.GroupSize {
+		return whole, false
+	}
+	for _, group := range groups[1:] {
+		if len(group) != locale.GroupSize {
+			return whole, false
+		}
+	}
+	return strings.Join(groups, ""), true
+}
+
+func (locale Locale) placeSymbol(number string, symbol string) string {
+	spacing := ""
+	if locale.SymbolSpacing {
+		spacing = " "
+	}
+	switch locale.SymbolPlacement {
+	case SymbolBefore:
+		return symbol + spacing + number
+	case SymbolAfter:
+		return number + spacing + symbol
+	}
+	return number
+}
+
+// strip the currency's symbol or code from either end of the number
+func trimSymbol(number string, currency Currency) string {
+	for _, symbol := range []string{currency.Symbol, currency.Code} {
+		if symbol == "" {
+			continue
+		}
+		if strings.HasPrefix(number, symbol) {
+			return strings.TrimLeftFunc(strings.TrimPrefix(number, symbol), unicode.IsSpace)
+		}
+		if strings.HasSuffix(number, symbol) {
+			return strings.TrimRightFunc(strings.TrimSuffix(number, symbol), unicode.IsSpace)
+		}
+	}
+	return number
+}
+
+// remove a leading or trailing '-', unless the amount is already negative
+func cutSign(number string, negative bool) (string, bool) {
+	if negative {
+		return number, true
+	}
+	if strings.HasPrefix(number, "-") {
+		return number[1:], true
+	}
+	if strings.HasSuffix(number, "-") {
+		return strings.TrimRightFunc(number[:len(number)-1], unicode.IsSpace), true
+	}
+	return number, false
+}
@@ -0,0 +1,81 @@
+package assets
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLocaleFormat(t *testing.T) {
+	usd, _ := NewUSDFromString("1234567.89")
+	btc, _ := NewBitcoinFromString("0.015")
+	euro, _ := ParseAmount(CurrencyEUR, "1234567.89")
+	negative, _ := NewUSDFromString("-1234.5")
+	cases := []struct {
+		actual   string
+		expected string
+	}{
+		{LocaleEnUS.Format(usd.Amount()), "$1,234,567.89"},
+		{LocaleDeDE.Format(euro), "1.234.567,89 €"},
+		{LocaleFrFR.Format(euro), "1 234 567,89 €"},
+		{LocaleEnUS.FormatDecimals(btc.Amount(), 4), "₿0.0150"},
+		{LocaleEnUS.Format(negative.Amount()), "-$1,234.50"},
+		{LocaleEnUSAccounting.Format(negative.Amount()), "($1,234.50)"},
+		{LocaleDeDE.Format(NewAmountFromInt(CurrencyEUR, -5)), "-0,05 €"},
+		{LocaleEnUS.FormatDecimals(usd.Amount(), 0), "$1,234,568"},
+		{LocaleEnUS.Format(NewAmountFromInt(CurrencyUSD, 99900)), "$999.00"},
+	}
+	for _, c := range cases {
+		if c.actual != c.expected {
+			t.Errorf("Expected %s but got %s", c.expected, c.actual)
+		}
+	}
+}
+
+func TestLocaleParse(t *testing.T) {
+	cases := []struct {
+		locale   Locale
+		currency Currency
+		input    string
+		expected string
+	}{
+		{LocaleEnUS, CurrencyUSD, "$1,234,567.89", "1234567.89"},
+		{LocaleEnUS, CurrencyUSD, "-$1,234.50", "-1234.50"},
+		{LocaleEnUS, CurrencyUSD, "$-1,234.50", "-1234.50"},
+		{LocaleEnUSAccounting, CurrencyUSD, "($1,234.50)", "-1234.50"},
+		{LocaleEnUS, CurrencyUSD, "1234.5 USD", "1234.50"},
+		{LocaleDeDE, CurrencyEUR, "1.234.567,89 €", "1234567.89"},
+		{LocaleFrFR, CurrencyEUR, "1 234,5 €", "1234.50"},
+		{LocaleEnUS, CurrencyBTC, "₿0.0150", "0.01500000"},
+		{LocaleEnUS, CurrencyUSD, "$1.00-", "-1.00"},
+		{LocaleDeDE, CurrencyEUR, "1.234,50 €-", "-1234.50"},
+		{LocaleDeDE, CurrencyEUR, "1.234,50- €", "-1234.50"},
+	}
+	for _, c := range cases {
+		amount, err := c.locale.Parse(c.currency, c.input)
+		if err != nil {
+			t.Errorf("Error parsing [%s] %v", c.input, err)
+			continue
+		}
+		if amount.GetStringValue() != c.expected {
+			t.Errorf("Expected %s for [%s] but got %s", c.expected, c.input, amount.GetStringValue())
+		}
+	}
+}
+
+func TestLocaleParseErrors(t *testing.T) {
+	if _, err := LocaleEnUS.Parse(CurrencyUSD, "$12,34.00"); !errors.Is(err, ErrInvalidGrouping) {
+		t.Errorf("Expected invalid grouping but got %v", err)
+	}
+	if _, err := LocaleEnUS.Parse(CurrencyUSD, "$1.005"); !errors.Is(err, ErrExcessPrecision) {
+		t.Errorf("Expected excess precision but got %v", err)
+	}
+	if _, err := LocaleDeDE.Parse(CurrencyEUR, "1,234.56 €"); err == nil {
+		t.Error("Expected error parsing US formatting with the German locale")
+	}
+	if _, err := LocaleEnUS.Parse(CurrencyUSD, "-$1.00-"); !errors.Is(err, ErrInvalidCharacter) {
+		t.Errorf("Expected invalid character for two signs but got %v", err)
+	}
+	if _, err := LocaleEnUS.Parse(CurrencyUSD, "  "); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected empty but got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package assets
+
+import (
+	"math"
+	"math/big"
+)
+
+// OverflowError result of an arithmetic operation does not fit in an int64
+type OverflowError struct {
+	message string
+}
+
+func (err OverflowError) Error() string {
+	return err.message
+}
+
+func newOverflowError(operation string, result *big.Int) OverflowError {
+	return OverflowError{message: "Overflow in " + operation + " -- result [" + result.String() + "] does not fit in int64"}
+}
+
+func bigToInt64Checked(value *big.Int, operation string) (int64, error) {
+	if !value.IsInt64() {
+		return 0, newOverflowError(operation, value)
+	}
+	return value.Int64(), nil
+}
+
+// clamp the amount to the int64 range, unchecked arithmetic on int64 backed assets saturates
+// rather than holding a value GetIntValue cannot return
+func saturateInt64(amount Amount) Amount {
+	if amount.minorUnits().IsInt64() {
+		return amount
+	}
+	return amount.withValue(big.NewInt(clampInt64(amount.minorUnits())))
+}
+
+// the value clamped to the int64 range
+func clampInt64(value *big.Int) int64 {
+	switch {
+	case value.IsInt64():
+		return value.Int64()
+	case value.Sign() < 0:
+		return math.MinInt64
+	default:
+		return math.MaxInt64
+	}
+}
+
+func pow10Big(power int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(power), nil)
+}
@@ -0,0 +1,231 @@
+package assets
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Price quote for a currency pair, the number of quote units one whole base unit is worth.
+// The rate is held as an integer with its own precision, independent of either currency's decimals
+type Price struct {
+	base      Currency
+	quote     Currency
+	rate      *big.Int
+	precision int64
+}
+
+// NewPrice create a price from a rate of rate / 10^precision quote units per base unit
+func NewPrice(base, quote Currency, rate *big.Int, precision int64) (Price, error) {
+	if base.Code == quote.Code {
+		return Price{}, CurrencyError{message: "Price base and quote are both [" + base.Code + "]"}
+	}
+	if rate.Sign() <= 0 {
+		return Price{}, ConversionError{message: "Price rate must be positive for [" + base.Code + "/" + quote.Code + "]"}
+	}
+	if precision < 0 {
+		return Price{}, ConversionError{message: "Price precision must not be negative for [" + base.Code + "/" + quote.Code + "]"}
+	}
+	return Price{base: base, quote: quote, rate: new(big.Int).Set(rate), precision: precision}, nil
+}
+
+// ParsePrice create a price from a decimal rate, the precision is the number of fraction digits given
+func ParsePrice(base, quote Currency, rateString string) (Price, error) {
+	precision := int64(0)
+	if _, fraction, found := strings.Cut(rateString, amountSeparator); found {
+		precision = int64(len(fraction))
+	}
+	rate, err := ParseAmountStrict(Currency{Code: base.Code + "/" + quote.Code, Decimals: precision}, rateString)
+	if err != nil {
+		return Price{}, err
+	}
+	return NewPrice(base, quote, rate.minorUnits(), precision)
+}
+
+// NewPriceFromUSD the price of one whole base unit in USD, as used by GetCost
+func NewPriceFromUSD(base Currency, price USD) (Price, error) {
+	return NewPrice(base, CurrencyUSD, price.Amount().minorUnits(), CurrencyUSD.Decimals)
+}
+
+// Base the currency being priced
+func (price Price) Base() Currency {
+	return price.base
+}
+
+// Quote the currency the price is in
+func (price Price) Quote() Currency {
+	return price.quote
+}
+
+// Rate the rate as an integer number of 10^-Precision quote units
+func (price Price) Rate() *big.Int {
+	return new(big.Int).Set(price.rate)
+}
+
+// Precision number of fraction digits held by the rate
+func (price Price) Precision() int64 {
+	return price.precision
+}
+
+// GetStringValue the rate as a decimal string
+func (price Price) GetStringValue() string {
+	return formatMinorUnits(price.rate, price.precision, price.precision)
+}
+
+// String the pair and rate, BTC/USD 60000.00
+func (price Price) String() string {
+	return price.base.Code + "/" + price.quote.Code + " " + price.GetStringValue()
+}
+
+// USD the price as a USD for use with GetCost, rounding half up to cents, when the quote currency is USD
+func (price Price) USD() (USD, error) {
+	if price.quote.Code != CurrencyUSD.Code {
+		return nil, newCurrencyMismatchError("price usd", CurrencyUSD, price.quote)
+	}
+	cents := quoRound(new(big.Int).Mul(price.rate, pow10Big(CurrencyUSD.Decimals)), pow10Big(price.precision), RoundHalfUp)
+	return newUSDFromAmountChecked(NewAmount(CurrencyUSD, cents), "price usd")
+}
+
+// Convert convert an amount of either currency into the other, rounding half up
+func (price Price) Convert(amount Amount) (Amount, error) {
+	return price.ConvertRounded(amount, RoundHalfUp)
+}
+
+// ConvertRounded convert an amount of either currency into the other, rounding with the rounding mode
+func (price Price) ConvertRounded(amount Amount, mode RoundingMode) (Amount, error) {
+	target, rate, ok := price.unitValue(amount.currency)
+	if !ok {
+		return Amount{}, CurrencyError{message: "Cannot convert [" + amount.currency.Code + "] with price [" + price.base.Code + "/" + price.quote.Code + "]"}
+	}
+	return convertAmount(amount, target, rate, mode), nil
+}
+
+// Invert the price of the quote currency in the base currency, keeping as many significant digits as the rate
+func (price Price) Invert() Price {
+	inverse := price.rat()
+	inverse.Inv(inverse)
+	// keeping the rate's significant digits never rounds to zero
+	inverted, _ := price.withRat(price.quote, price.base, inverse, significantPrecision(inverse, decimalDigits(price.rate)), RoundHalfUp)
+	return inverted
+}
+
+// InvertRounded the price of the quote currency in the base currency at the precision,
+// an error if the inverse rounds to zero at that precision
+func (price Price) InvertRounded(precision int64, mode RoundingMode) (Price, error) {
+	inverse := price.rat()
+	inverse.Inv(inverse)
+	return price.withRat(price.quote, price.base, inverse, precision, mode)
+}
+
+// Cross triangulate through a currency shared by both prices, BTC/USD crossed with ETH/USD gives BTC/ETH.
+// The result prices this price's other currency in the other price's other currency, keeping as many
+// significant digits as the more precise rate
+func (price Price) Cross(other Price) (Price, error) {
+	base, quote, rate, err := price.cross(other)
+	if err != nil {
+		return Price{}, err
+	}
+	significantDigits := decimalDigits(price.rate)
+	if otherDigits := decimalDigits(other.rate); otherDigits > significantDigits {
+		significantDigits = otherDigits
+	}
+	return price.withRat(base, quote, rate, significantPrecision(rate, significantDigits), RoundHalfUp)
+}
+
+// CrossRounded triangulate through a shared currency, rounding the rate to the precision,
+// an error if the rate rounds to zero at that precision
+func (price Price) CrossRounded(other Price, precision int64, mode RoundingMode) (Price, error) {
+	base, quote, rate, err := price.cross(other)
+	if err != nil {
+		return Price{}, err
+	}
+	return price.withRat(base, quote, rate, precision, mode)
+}
+
+func (price Price) cross(other Price) (Currency, Currency, *big.Rat, error) {
+	for _, shared := range []Currency{price.quote, price.base} {
+		if shared.Code != other.base.Code && shared.Code != other.quote.Code {
+			continue
+		}
+		// value of one unit of each non-shared currency in the shared currency
+		base, baseValue := price.otherCurrency(shared)
+		quote, quoteValue := other.otherCurrency(shared)
+		if base.Code == quote.Code {
+			break
+		}
+		return base, quote, baseValue.Quo(baseValue, quoteValue), nil
+	}
+	return Currency{}, Currency{}, nil, CurrencyError{message: "Cannot cross [" + price.base.Code + "/" + price.quote.Code + "] with [" + other.base.Code + "/" + other.quote.Code + "]"}
+}
+
+// the currency that is not shared and the value of one unit of it in the shared currency
+func (price Price) otherCurrency(shared Currency) (Currency, *big.Rat) {
+	if price.quote.Code == shared.Code {
+		return price.base, price.rat()
+	}
+	rate := price.rat()
+	return price.quote, rate.Inv(rate)
+}
+
+// the other currency of the pair and the value of one unit of currency in it
+func (price Price) unitValue(currency Currency) (Currency, *big.Rat, bool) {
+	switch currency.Code {
+	case price.base.Code:
+		return price.quote, price.rat(), true
+	case price.quote.Code:
+		rate := price.rat()
+		return price.base, rate.Inv(rate), true
+	}
+	return Currency{}, nil, false
+}
+
+func (price Price) rat() *big.Rat {
+	return new(big.Rat).SetFrac(price.rate, pow10Big(price.precision))
+}
+
+func (price Price) withRat(base, quote Currency, rate *big.Rat, precision int64, mode RoundingMode) (Price, error) {
+	scaled := new(big.Rat).Mul(rate, new(big.Rat).SetInt(pow10Big(precision)))
+	rounded := quoRound(scaled.Num(), scaled.Denom(), mode)
+	if rounded.Sign() <= 0 {
+		return Price{}, ConversionError{message: "Price rate for [" + base.Code + "/" + quote.Code + "] rounds to zero at precision " + strconv.FormatInt(precision, 10)}
+	}
+	return Price{base: base, quote: quote, rate: rounded, precision: precision}, nil
+}
+
+// convert at rate target units per unit of the amount's currency, rounding once to the target's minor units
+func convertAmount(amount Amount, target Currency, rate *big.Rat, mode RoundingMode) Amount {
+	// minor units of the target = minor units * rate * 10^(target decimals - source decimals)
+	converted := new(big.Rat).SetInt(amount.minorUnits())
+	converted.Mul(converted, rate)
+	converted.Mul(converted, new(big.Rat).SetFrac(pow10Big(target.Decimals), pow10Big(amount.currency.Decimals)))
+	return NewAmount(target, quoRound(converted.Num(), converted.Denom(), mode))
+}
+
+func decimalDigits(value *big.Int) int64 {
+	return int64(len(new(big.Int).Abs(value).String()))
+}
+
+// fraction digits needed to show a positive rate with the number of significant digits
+func significantPrecision(rate *big.Rat, significantDigits int64) int64 {
+	// exponent is floor(log10(rate)), start from the digit counts and correct by one if needed
+	exponent := decimalDigits(rate.Num()) - decimalDigits(rate.Denom())
+	power := new(big.Rat).SetInt(pow10Big(abs64(exponent)))
+	if exponent < 0 {
+		power.Inv(power)
+	}
+	if rate.Cmp(power) < 0 {
+		exponent--
+	}
+	precision := significantDigits - 1 - exponent
+	if precision < 0 {
+		return 0
+	}
+	return precision
+}
+
+func abs64(value int64) int64 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,124 @@
+package assets
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestParsePrice(t *testing.T) {
+	price, err := ParsePrice(CurrencyBTC, CurrencyUSD, "60000.125")
+	if err != nil {
+		t.Fatalf("Error parsing price %v", err)
+	}
+	if price.Precision() != 3 || price.Rate().Int64() != 60000125 {
+		t.Errorf("Invalid rate %s at precision %d", price.Rate(), price.Precision())
+	}
+	if price.String() != "BTC/USD 60000.125" {
+		t.Errorf("Invalid price string %s", price.String())
+	}
+	if _, err := ParsePrice(CurrencyBTC, CurrencyUSD, "-1"); err == nil {
+		t.Error("Expected error for negative rate")
+	}
+	if _, err := NewPrice(CurrencyBTC, CurrencyUSD, big.NewInt(1), -1); err == nil || !strings.Contains(err.Error(), "precision") {
+		t.Errorf("Expected precision error for negative precision, got %v", err)
+	}
+	if _, err := ParsePrice(CurrencyBTC, CurrencyBTC, "1"); err == nil {
+		t.Error("Expected error for same base and quote")
+	}
+	if _, err := ParsePrice(CurrencyBTC, CurrencyUSD, "1,000"); !errors.Is(err, ErrInvalidCharacter) {
+		t.Errorf("Expected invalid character error, got %v", err)
+	}
+}
+
+func TestPriceConvert(t *testing.T) {
+	price, _ := ParsePrice(CurrencyBTC, CurrencyUSD, "60000.125")
+	btc, _ := ParseAmount(CurrencyBTC, "0.5")
+	usd, err := price.Convert(btc)
+	if err != nil || usd.Currency() != CurrencyUSD || usd.GetStringValue() != "30000.06" {
+		t.Errorf("Invalid conversion %s %v", usd.GetStringValue(), err)
+	}
+	truncated, _ := price.ConvertRounded(btc, RoundTruncate)
+	if truncated.GetStringValue() != "30000.06" {
+		t.Errorf("Invalid truncated conversion %s", truncated.GetStringValue())
+	}
+	back, err := price.Convert(NewAmountFromInt(CurrencyUSD, 3000000))
+	if err != nil || back.Currency() != CurrencyBTC || back.GetStringValue() != "0.49999896" {
+		t.Errorf("Invalid reverse conversion %s %v", back.GetStringValue(), err)
+	}
+	if _, err := price.Convert(ZeroAmount(CurrencyETH)); err == nil {
+		t.Error("Expected error converting a currency not in the pair")
+	}
+}
+
+func TestPriceMatchesGetCost(t *testing.T) {
+	usdPrice, _ := NewUSDFromString("43210.99")
+	price, err := NewPriceFromUSD(CurrencyBTC, usdPrice)
+	if err != nil {
+		t.Fatalf("Error creating price %v", err)
+	}
+	btc, _ := NewBitcoinFromString("1.23456789")
+	converted, _ := price.ConvertRounded(btc.Amount(), RoundTruncate)
+	if cost := btc.GetCost(usdPrice); cost.GetIntValue() != converted.GetIntValue() {
+		t.Errorf("Price conversion %s does not match cost %s", converted.GetStringValue(), cost.GetStringValue())
+	}
+	roundTrip, err := price.USD()
+	if err != nil || roundTrip.GetIntValue() != usdPrice.GetIntValue() {
+		t.Errorf("Invalid usd price %v %v", roundTrip, err)
+	}
+	ethPrice, _ := ParsePrice(CurrencyBTC, CurrencyETH, "20")
+	if _, err := ethPrice.USD(); err == nil {
+		t.Error("Expected error for a price not quoted in USD")
+	}
+}
+
+func TestPriceInvert(t *testing.T) {
+	price, _ := ParsePrice(CurrencyBTC, CurrencyUSD, "60000.00")
+	inverse := price.Invert()
+	if inverse.Base() != CurrencyUSD || inverse.Quote() != CurrencyBTC {
+		t.Errorf("Invalid inverse pair %s", inverse)
+	}
+	if inverse.GetStringValue() != "0.00001666667" {
+		t.Errorf("Invalid inverse rate %s", inverse.GetStringValue())
+	}
+	rounded, err := price.InvertRounded(8, RoundTruncate)
+	if err != nil || rounded.GetStringValue() != "0.00001666" {
+		t.Errorf("Invalid rounded inverse rate %s %v", rounded.GetStringValue(), err)
+	}
+	if zero, err := price.InvertRounded(2, RoundHalfUp); err == nil {
+		t.Errorf("Expected error for an inverse rounding to zero but got %s", zero.GetStringValue())
+	}
+	small, _ := ParsePrice(CurrencyUSD, CurrencyBTC, "0.00000005")
+	if small.Invert().GetStringValue() != "20000000" {
+		t.Errorf("Invalid inverse of small rate %s", small.Invert().GetStringValue())
+	}
+}
+
+func TestPriceCross(t *testing.T) {
+	btcUSD, _ := ParsePrice(CurrencyBTC, CurrencyUSD, "60000.00")
+	ethUSD, _ := ParsePrice(CurrencyETH, CurrencyUSD, "3100.00")
+	btcETH, err := btcUSD.Cross(ethUSD)
+	if err != nil {
+		t.Fatalf("Error crossing prices %v", err)
+	}
+	if btcETH.Base() != CurrencyBTC || btcETH.Quote() != CurrencyETH || btcETH.GetStringValue() != "19.35484" {
+		t.Errorf("Invalid cross %s", btcETH)
+	}
+	ethBTC, _ := ethUSD.Cross(btcUSD)
+	if ethBTC.String() != "ETH/BTC 0.05166667" {
+		t.Errorf("Invalid cross %s", ethBTC)
+	}
+	usdETH := ethUSD.Invert()
+	chained, _ := btcUSD.CrossRounded(usdETH, 2, RoundHalfEven)
+	if chained.String() != "BTC/ETH 19.35" {
+		t.Errorf("Invalid chained cross %s", chained)
+	}
+	if _, err := btcUSD.Cross(btcUSD); err == nil {
+		t.Error("Expected error crossing a price with itself")
+	}
+	euro, _ := ParsePrice(CurrencyEUR, CurrencyETH, "0.0003")
+	if _, err := btcUSD.Cross(euro); err == nil {
+		t.Error("Expected error crossing prices with no shared currency")
+	}
+}
@@ -0,0 +1,167 @@
+package assets
+
+import "sort"
+
+// Quantity constraint satisfied by Bitcoin, Ether and USD, each of which only combines with its own type,
+// so helpers over amounts can be written once, Sum[Bitcoin] or Clamp(usd, low, high). The unexported
+// fromAmount method keeps types outside this package from satisfying it
+type Quantity[T any] interface {
+	Asset
+	Add(T) T
+	Subtract(T) T
+	Compare(T) int
+	Sign() int
+	Amount() Amount
+	fromAmount(Amount) T
+}
+
+// Sum total of the values, returning an OverflowError if the total does not fit in the type
+func Sum[T Quantity[T]](values []T) (T, error) {
+	return quantityFromAmountChecked[T](sumAmounts(quantityCurrency[T](), quantityAmounts(values)), "sum")
+}
+
+// Min smallest of the values, the first one when several are equal
+func Min[T Quantity[T]](values []T) (T, error) {
+	index, err := extremeAmount(quantityCurrency[T](), "minimum", quantityAmounts(values), -1)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return values[index], nil
+}
+
+// Max largest of the values, the first one when several are equal
+func Max[T Quantity[T]](values []T) (T, error) {
+	index, err := extremeAmount(quantityCurrency[T](), "maximum", quantityAmounts(values), 1)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return values[index], nil
+}
+
+// Mean average of the values rounded to a minor unit with the rounding mode, the total may exceed an int64
+func Mean[T Quantity[T]](values []T, mode RoundingMode) (T, error) {
+	mean, err := meanAmount(quantityCurrency[T](), quantityAmounts(values), mode)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return quantityFromAmount[T](mean), nil
+}
+
+// Median middle of the values, the mean of the middle two rounded with the rounding mode for an even count
+func Median[T Quantity[T]](values []T, mode RoundingMode) (T, error) {
+	median, err := medianAmount(quantityCurrency[T](), quantityAmounts(values), mode)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return quantityFromAmount[T](median), nil
+}
+
+// Sort sort the values ascending in place, keeping the order of equal values
+func Sort[T Quantity[T]](values []T) {
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].Compare(values[j]) < 0
+	})
+}
+
+// Clamp the value limited to the range low to high, low must not be greater than high
+func Clamp[T Quantity[T]](value, low, high T) T {
+	if value.Compare(low) < 0 {
+		return low
+	}
+	if value.Compare(high) > 0 {
+		return high
+	}
+	return value
+}
+
+// Allocate split the value in proportion to the ratios without losing or inventing minor units, see Amount.Allocate
+func Allocate[T Quantity[T]](value T, ratios ...int64) ([]T, error) {
+	parts, err := value.Amount().Allocate(ratios...)
+	if err != nil {
+		return nil, err
+	}
+	return quantityParts(value, parts), nil
+}
+
+// SplitEvenly split the value into n parts differing by at most one minor unit, see Amount.SplitEvenly
+func SplitEvenly[T Quantity[T]](value T, n int) ([]T, error) {
+	parts, err := value.Amount().SplitEvenly(n)
+	if err != nil {
+		return nil, err
+	}
+	return quantityParts(value, parts), nil
+}
+
+// DivideByQuantity divides by a value of the same type, outputting the ratio in the specified fraction
+// decimal length, unlike DivideByAsset the values cannot be of different types. Panics if the divisor is zero
+func DivideByQuantity[T Quantity[T]](dividend, divisor T, outputFractionDecimalLength int64, mode RoundingMode) Asset {
+	return DivideByAssetRounded(dividend, divisor, outputFractionDecimalLength, mode)
+}
+
+// DivideQuantity divides by an int to the value's own precision, also returning the remainder
+// such that dividend = quotient * divisor + remainder. Panics if the divisor is zero
+func DivideQuantity[T Quantity[T]](dividend T, divisor int64, mode RoundingMode) (T, T) {
+	quotient, remainder := DivideWithRemainder(dividend, divisor, dividend.GetFractionLength(), mode)
+	currency := dividend.Amount().Currency()
+	return dividend.fromAmount(NewAmount(currency, assetBigValue(quotient))), dividend.fromAmount(NewAmount(currency, assetBigValue(remainder)))
+}
+
+func quantityAmounts[T Quantity[T]](values []T) []Amount {
+	amounts := make([]Amount, len(values))
+	for i, value := range values {
+		amounts[i] = value.Amount()
+	}
+	return amounts
+}
+
+func quantityParts[T Quantity[T]](value T, parts []Amount) []T {
+	values := make([]T, len(parts))
+	for i, part := range parts {
+		values[i] = value.fromAmount(part)
+	}
+	return values
+}
+
+// the currency of a quantity type, switching on a typed nil pointer as T is an interface and
+// there may be no value to ask. Quantity is sealed so the panics cannot be reached
+func quantityCurrency[T any]() Currency {
+	switch any((*T)(nil)).(type) {
+	case *Bitcoin:
+		return CurrencyBTC
+	case *Ether:
+		return CurrencyETH
+	case *USD:
+		return CurrencyUSD
+	}
+	panic("assets: unsupported quantity type")
+}
+
+func quantityFromAmount[T any](amount Amount) T {
+	var value any
+	switch any((*T)(nil)).(type) {
+	case *Bitcoin:
+		value = newBitcoinFromAmount(amount)
+	case *Ether:
+		value = newEtherFromAmount(amount)
+	case *USD:
+		value = newUSDFromAmount(amount)
+	default:
+		panic("assets: unsupported quantity type")
+	}
+	return value.(T)
+}
+
+func quantityFromAmountChecked[T any](amount Amount, operation string) (T, error) {
+	currency := quantityCurrency[T]()
+	if currency.Code != CurrencyETH.Code {
+		if _, err := bigToInt64Checked(amount.minorUnits(), currency.Code+" "+operation); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+	return quantityFromAmount[T](amount), nil
+}
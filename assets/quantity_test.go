@@ -0,0 +1,90 @@
+package assets
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestQuantityHelpers(t *testing.T) {
+	usds := []USD{NewUSDFromInt(300), NewUSDFromInt(-100), NewUSDFromInt(200)}
+	sum, err := Sum(usds)
+	if err != nil || sum.GetIntValue() != 400 {
+		t.Errorf("Invalid sum %v %v", sum, err)
+	}
+	minimum, _ := Min(usds)
+	maximum, _ := Max(usds)
+	if minimum.GetIntValue() != -100 || maximum.GetIntValue() != 300 {
+		t.Errorf("Invalid min %d or max %d", minimum.GetIntValue(), maximum.GetIntValue())
+	}
+	mean, _ := Mean(usds, RoundCeiling)
+	median, _ := Median(usds, RoundHalfUp)
+	if mean.GetIntValue() != 134 || median.GetIntValue() != 200 {
+		t.Errorf("Invalid mean %d or median %d", mean.GetIntValue(), median.GetIntValue())
+	}
+	Sort(usds)
+	if usds[0].GetIntValue() != -100 || usds[2].GetIntValue() != 300 {
+		t.Errorf("Not sorted %v", usds)
+	}
+	empty, err := Sum([]Ether{})
+	if err != nil || !empty.IsZero() || empty.Amount().Currency() != CurrencyETH {
+		t.Errorf("Invalid empty sum %v %v", empty, err)
+	}
+	if _, err := Sum([]Bitcoin{NewBitcoinFromInt(math.MaxInt64), NewBitcoinFromInt(1)}); !errors.As(err, &OverflowError{}) {
+		t.Errorf("Expected overflow error, got %v", err)
+	}
+}
+
+func TestClamp(t *testing.T) {
+	low := NewBitcoinFromInt(100)
+	high := NewBitcoinFromInt(200)
+	for _, test := range []struct {
+		value    int64
+		expected int64
+	}{
+		{50, 100},
+		{150, 150},
+		{250, 200},
+	} {
+		if clamped := Clamp(NewBitcoinFromInt(test.value), low, high); clamped.GetIntValue() != test.expected {
+			t.Errorf("Clamp of %d gave %d, expected %d", test.value, clamped.GetIntValue(), test.expected)
+		}
+	}
+}
+
+func TestGenericAllocate(t *testing.T) {
+	eth := NewEtherFromInt(10)
+	parts, err := Allocate(eth, 1, 2)
+	if err != nil || parts[0].GetIntValue() != 3 || parts[1].GetIntValue() != 7 {
+		t.Errorf("Invalid allocation %v %v", parts, err)
+	}
+	split, _ := SplitEvenly[Ether](eth, 4)
+	if sum, err := SumEther(split); err != nil || len(split) != 4 || sum.Compare(eth) != 0 {
+		t.Errorf("Invalid split %v", split)
+	}
+}
+
+func TestDivideQuantity(t *testing.T) {
+	usd, _ := NewUSDFromString("10.00")
+	quotient, remainder := DivideQuantity(usd, 3, RoundHalfUp)
+	if quotient.GetStringValue() != "3.33" || remainder.GetStringValue() != "0.01" {
+		t.Errorf("Invalid division %s rem %s", quotient.GetStringValue(), remainder.GetStringValue())
+	}
+	if quotient.Add(quotient).Add(quotient).Add(remainder).Compare(usd) != 0 {
+		t.Error("Quotient and remainder do not sum to the dividend")
+	}
+	btc := NewBitcoinFromInt(-7)
+	btcQuotient, btcRemainder := DivideQuantity(btc, 2, RoundFloor)
+	if btcQuotient.GetIntValue() != -4 || btcRemainder.GetIntValue() != 1 {
+		t.Errorf("Invalid floor division %d rem %d", btcQuotient.GetIntValue(), btcRemainder.GetIntValue())
+	}
+}
+
+func TestDivideByQuantity(t *testing.T) {
+	held, _ := NewBitcoinFromString("1.5")
+	lot, _ := NewBitcoinFromString("0.4")
+	lots := DivideByQuantity(held, lot, 2, RoundTruncate)
+	if lots.GetIntValue() != 375 || lots.GetFractionLength() != 2 {
+		t.Errorf("Invalid ratio %d at fraction length %d", lots.GetIntValue(), lots.GetFractionLength())
+	}
+}
@@ -0,0 +1,207 @@
+package assets
+
+import (
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+)
+
+// StalePolicy what a rate table does with a conversion that uses a quote older than its max age
+type StalePolicy int
+
+const (
+	// StaleReject return a StaleQuoteError
+	StaleReject StalePolicy = iota
+	// StaleFlag convert anyway and set Stale on the conversion
+	StaleFlag
+)
+
+// RateQuote a price and the time it was quoted
+type RateQuote struct {
+	Price Price
+	Time  time.Time
+}
+
+// Conversion result of converting through a rate table
+type Conversion struct {
+	Amount Amount
+	// Path currencies converted through, starting with the source and ending with the target
+	Path []Currency
+	// Oldest time of the oldest quote used
+	Oldest time.Time
+	// Stale set when a quote used was older than the table's max age
+	Stale bool
+}
+
+// StaleQuoteError a conversion needed a quote older than the rate table's max age
+type StaleQuoteError struct {
+	Pair   string
+	Age    time.Duration
+	MaxAge time.Duration
+}
+
+func (err StaleQuoteError) Error() string {
+	return "Quote for [" + err.Pair + "] is " + err.Age.String() + " old, max age is " + err.MaxAge.String()
+}
+
+// RateTable latest quotes for many currency pairs, converting between any two currencies connected
+// by quotes through the fewest pairs. Safe for concurrent use
+type RateTable struct {
+	mutex  sync.RWMutex
+	maxAge time.Duration
+	policy StalePolicy
+	quotes map[string]map[string]RateQuote
+}
+
+// NewRateTable create an empty rate table, a maxAge of zero never treats quotes as stale
+func NewRateTable(maxAge time.Duration, policy StalePolicy) *RateTable {
+	return &RateTable{maxAge: maxAge, policy: policy, quotes: map[string]map[string]RateQuote{}}
+}
+
+// Set store a quote for the price's pair, replacing any quote for the pair in either direction
+// unless that quote is newer
+func (table *RateTable) Set(price Price, at time.Time) {
+	table.mutex.Lock()
+	defer table.mutex.Unlock()
+	if existing, ok := table.quotes[price.base.Code][price.quote.Code]; ok && existing.Time.After(at) {
+		return
+	}
+	quote := RateQuote{Price: price, Time: at}
+	table.setEdge(price.base.Code, price.quote.Code, quote)
+	table.setEdge(price.quote.Code, price.base.Code, quote)
+}
+
+func (table *RateTable) setEdge(from, to string, quote RateQuote) {
+	if table.quotes[from] == nil {
+		table.quotes[from] = map[string]RateQuote{}
+	}
+	table.quotes[from][to] = quote
+}
+
+// Lookup the quote stored for a pair, in either direction
+func (table *RateTable) Lookup(base, quote Currency) (RateQuote, bool) {
+	table.mutex.RLock()
+	defer table.mutex.RUnlock()
+	rateQuote, ok := table.quotes[base.Code][quote.Code]
+	return rateQuote, ok
+}
+
+// Convert convert an amount to the target currency using the current time, rounding half up
+func (table *RateTable) Convert(amount Amount, target Currency) (Conversion, error) {
+	return table.ConvertAt(amount, target, time.Now(), RoundHalfUp)
+}
+
+// ConvertAt convert an amount to the target currency, judging staleness at now. The rates along
+// the path are multiplied exactly and the result is rounded once with the rounding mode
+func (table *RateTable) ConvertAt(amount Amount, target Currency, now time.Time, mode RoundingMode) (Conversion, error) {
+	quotes, err := table.route(amount.currency, target)
+	if err != nil {
+		return Conversion{}, err
+	}
+	conversion, rate, err := table.walk(amount.currency, quotes, now)
+	if err != nil {
+		return Conversion{}, err
+	}
+	conversion.Amount = convertAmount(amount, target, rate, mode)
+	return conversion, nil
+}
+
+// Price the price of one unit of base in quote at now through the fewest pairs, keeping as many
+// significant digits as the most precise rate used
+func (table *RateTable) Price(base, quote Currency, now time.Time) (Price, Conversion, error) {
+	if base.Code == quote.Code {
+		return Price{}, Conversion{}, CurrencyError{message: "Price base and quote are both [" + base.Code + "]"}
+	}
+	quotes, err := table.route(base, quote)
+	if err != nil {
+		return Price{}, Conversion{}, err
+	}
+	conversion, rate, err := table.walk(base, quotes, now)
+	if err != nil {
+		return Price{}, Conversion{}, err
+	}
+	significantDigits := int64(1)
+	for _, rateQuote := range quotes {
+		if digits := decimalDigits(rateQuote.Price.rate); digits > significantDigits {
+			significantDigits = digits
+		}
+	}
+	price, err := Price{}.withRat(base, quote, rate, significantPrecision(rate, significantDigits), RoundHalfUp)
+	if err != nil {
+		return Price{}, Conversion{}, err
+	}
+	return price, conversion, nil
+}
+
+// Cost the cost of an amount in USD at now, truncating fractions of a cent as GetCost does
+func (table *RateTable) Cost(amount Amount, now time.Time) (USD, error) {
+	conversion, err := table.ConvertAt(amount, CurrencyUSD, now, RoundTruncate)
+	if err != nil {
+		return nil, err
+	}
+	return newUSDFromAmountChecked(conversion.Amount, "rate table cost")
+}
+
+// the quotes along the shortest path between two currencies
+func (table *RateTable) route(from, to Currency) ([]RateQuote, error) {
+	table.mutex.RLock()
+	defer table.mutex.RUnlock()
+	quotes, ok := table.shortestPath(from.Code, to.Code)
+	if !ok {
+		return nil, CurrencyError{message: "No rate from [" + from.Code + "] to [" + to.Code + "]"}
+	}
+	return quotes, nil
+}
+
+// multiply the rates along a path of quotes starting from a currency, checking each quote's age
+func (table *RateTable) walk(from Currency, quotes []RateQuote, now time.Time) (Conversion, *big.Rat, error) {
+	conversion := Conversion{Path: []Currency{from}, Oldest: now}
+	rate := big.NewRat(1, 1)
+	current := from
+	for _, quote := range quotes {
+		if age := now.Sub(quote.Time); table.maxAge > 0 && age > table.maxAge {
+			if table.policy == StaleReject {
+				return Conversion{}, nil, StaleQuoteError{Pair: quote.Price.base.Code + "/" + quote.Price.quote.Code, Age: age, MaxAge: table.maxAge}
+			}
+			conversion.Stale = true
+		}
+		if quote.Time.Before(conversion.Oldest) {
+			conversion.Oldest = quote.Time
+		}
+		next, unitValue, _ := quote.Price.unitValue(current)
+		rate.Mul(rate, unitValue)
+		conversion.Path = append(conversion.Path, next)
+		current = next
+	}
+	return conversion, rate, nil
+}
+
+// breadth first search over currency codes, visiting neighbours in code order so ties are deterministic
+func (table *RateTable) shortestPath(from, to string) ([]RateQuote, bool) {
+	previous := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == to {
+			var quotes []RateQuote
+			for code := to; code != from; code = previous[code] {
+				quotes = append([]RateQuote{table.quotes[previous[code]][code]}, quotes...)
+			}
+			return quotes, true
+		}
+		neighbours := make([]string, 0, len(table.quotes[current]))
+		for code := range table.quotes[current] {
+			neighbours = append(neighbours, code)
+		}
+		sort.Strings(neighbours)
+		for _, code := range neighbours {
+			if _, seen := previous[code]; !seen {
+				previous[code] = current
+				queue = append(queue, code)
+			}
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,141 @@
+package assets
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+var testQuoteTime = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func testRateTable(t *testing.T, maxAge time.Duration, policy StalePolicy) *RateTable {
+	table := NewRateTable(maxAge, policy)
+	for _, quote := range []struct {
+		base, quote Currency
+		rate        string
+		age         time.Duration
+	}{
+		{CurrencyBTC, CurrencyUSD, "60000.00", 0},
+		{CurrencyETH, CurrencyUSD, "3000.00", time.Minute},
+		{CurrencyEUR, CurrencyUSD, "1.0850", 2 * time.Hour},
+	} {
+		price, err := ParsePrice(quote.base, quote.quote, quote.rate)
+		if err != nil {
+			t.Fatalf("Error parsing price %v", err)
+		}
+		table.Set(price, testQuoteTime.Add(-quote.age))
+	}
+	return table
+}
+
+func TestRateTableConvertThroughUSD(t *testing.T) {
+	table := testRateTable(t, time.Hour, StaleReject)
+	eth, _ := ParseAmount(CurrencyETH, "2")
+	conversion, err := table.ConvertAt(eth, CurrencyBTC, testQuoteTime, RoundHalfUp)
+	if err != nil {
+		t.Fatalf("Error converting %v", err)
+	}
+	if conversion.Amount.Currency() != CurrencyBTC || conversion.Amount.GetStringValue() != "0.10000000" {
+		t.Errorf("Invalid conversion %s", conversion.Amount.GetStringValue())
+	}
+	if len(conversion.Path) != 3 || conversion.Path[1] != CurrencyUSD {
+		t.Errorf("Invalid path %v", conversion.Path)
+	}
+	if conversion.Stale || !conversion.Oldest.Equal(testQuoteTime.Add(-time.Minute)) {
+		t.Errorf("Invalid staleness %v %v", conversion.Stale, conversion.Oldest)
+	}
+}
+
+func TestRateTableRoundsOnce(t *testing.T) {
+	table := NewRateTable(0, StaleReject)
+	btcUSD, _ := ParsePrice(CurrencyBTC, CurrencyUSD, "3")
+	usdETH, _ := ParsePrice(CurrencyUSD, CurrencyETH, "0.5")
+	table.Set(btcUSD, testQuoteTime)
+	table.Set(usdETH, testQuoteTime)
+	// 1 satoshi is 0.00000003 USD, less than a cent, but 0.000000015 ETH
+	conversion, err := table.ConvertAt(NewAmountFromInt(CurrencyBTC, 1), CurrencyETH, testQuoteTime, RoundHalfUp)
+	if err != nil || conversion.Amount.GetIntValue() != 15000000000 {
+		t.Errorf("Invalid conversion %s %v", conversion.Amount.GetStringValue(), err)
+	}
+}
+
+func TestRateTableStaleness(t *testing.T) {
+	eur, _ := ParseAmount(CurrencyEUR, "100")
+	_, err := testRateTable(t, time.Hour, StaleReject).ConvertAt(eur, CurrencyBTC, testQuoteTime, RoundHalfUp)
+	var staleErr StaleQuoteError
+	if !errors.As(err, &staleErr) || staleErr.Pair != "EUR/USD" || staleErr.Age != 2*time.Hour {
+		t.Fatalf("Expected stale quote error, got %v", err)
+	}
+	conversion, err := testRateTable(t, time.Hour, StaleFlag).ConvertAt(eur, CurrencyBTC, testQuoteTime, RoundHalfUp)
+	if err != nil || !conversion.Stale || conversion.Amount.GetStringValue() != "0.00180833" {
+		t.Errorf("Invalid flagged conversion %s %v %v", conversion.Amount.GetStringValue(), conversion.Stale, err)
+	}
+	if _, err := testRateTable(t, 0, StaleReject).ConvertAt(eur, CurrencyBTC, testQuoteTime, RoundHalfUp); err != nil {
+		t.Errorf("Expected no max age to accept old quotes, got %v", err)
+	}
+}
+
+func TestRateTablePrefersShortestPath(t *testing.T) {
+	table := testRateTable(t, 0, StaleReject)
+	ethBTC, _ := ParsePrice(CurrencyETH, CurrencyBTC, "0.04")
+	table.Set(ethBTC, testQuoteTime)
+	conversion, err := table.ConvertAt(NewAmountFromInt(CurrencyBTC, 100000000), CurrencyETH, testQuoteTime, RoundHalfUp)
+	if err != nil || len(conversion.Path) != 2 || conversion.Amount.GetStringValue() != "25.000000000000000000" {
+		t.Errorf("Invalid direct conversion %s %v %v", conversion.Amount.GetStringValue(), conversion.Path, err)
+	}
+	if _, err := table.ConvertAt(NewAmountFromInt(CurrencyBTC, 1), Currency{Code: "JPY"}, testQuoteTime, RoundHalfUp); err == nil {
+		t.Error("Expected error converting to a currency with no quotes")
+	}
+}
+
+func TestRateTableSetKeepsNewest(t *testing.T) {
+	table := testRateTable(t, 0, StaleReject)
+	older, _ := ParsePrice(CurrencyUSD, CurrencyBTC, "0.00002")
+	table.Set(older, testQuoteTime.Add(-time.Hour))
+	if quote, _ := table.Lookup(CurrencyUSD, CurrencyBTC); quote.Price.String() != "BTC/USD 60000.00" {
+		t.Errorf("Older quote replaced newer %s", quote.Price)
+	}
+	newer, _ := ParsePrice(CurrencyUSD, CurrencyBTC, "0.00002")
+	table.Set(newer, testQuoteTime.Add(time.Minute))
+	if quote, _ := table.Lookup(CurrencyBTC, CurrencyUSD); quote.Price.String() != "USD/BTC 0.00002" {
+		t.Errorf("Newer quote not stored %s", quote.Price)
+	}
+}
+
+func TestRateTablePriceAndCost(t *testing.T) {
+	table := testRateTable(t, 0, StaleReject)
+	price, conversion, err := table.Price(CurrencyETH, CurrencyBTC, testQuoteTime)
+	if err != nil || price.String() != "ETH/BTC 0.05000000" || len(conversion.Path) != 3 {
+		t.Errorf("Invalid price %s %v", price, err)
+	}
+	btc, _ := NewBitcoinFromString("0.12345678")
+	cost, err := table.Cost(btc.Amount(), testQuoteTime)
+	usdPrice, _ := NewUSDFromString("60000.00")
+	if err != nil || cost.GetIntValue() != btc.GetCost(usdPrice).GetIntValue() {
+		t.Errorf("Invalid cost %v %v", cost, err)
+	}
+}
+
+func TestRateTableConcurrentReads(t *testing.T) {
+	table := testRateTable(t, 0, StaleReject)
+	eth, _ := ParseAmount(CurrencyETH, "1")
+	var wait sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wait.Add(1)
+		go func(i int) {
+			defer wait.Done()
+			for j := 0; j < 100; j++ {
+				if i%2 == 0 {
+					price, _ := ParsePrice(CurrencyETH, CurrencyUSD, "3000.00")
+					table.Set(price, testQuoteTime.Add(time.Duration(j)*time.Second))
+					continue
+				}
+				if _, err := table.ConvertAt(eth, CurrencyBTC, testQuoteTime, RoundHalfUp); err != nil {
+					t.Errorf("Error converting %v", err)
+				}
+			}
+		}(i)
+	}
+	wait.Wait()
+}
@@ -0,0 +1,76 @@
+package assets
+
+import "math/big"
+
+// RoundingMode how a result that cannot be represented exactly is rounded
+type RoundingMode int
+
+const (
+	// RoundHalfUp round to nearest, ties away from zero
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven round to nearest, ties to the even neighbour (banker's rounding)
+	RoundHalfEven
+	// RoundHalfDown round to nearest, ties toward zero
+	RoundHalfDown
+	// RoundFloor round toward negative infinity
+	RoundFloor
+	// RoundCeiling round toward positive infinity
+	RoundCeiling
+	// RoundTruncate round toward zero
+	RoundTruncate
+)
+
+// numerator / denominator rounded using the rounding mode
+func quoRound(numerator, denominator *big.Int, mode RoundingMode) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient
+	}
+	// sign of the exact result, the truncated quotient is one step toward zero from the next candidate
+	sign := numerator.Sign() * denominator.Sign()
+	if roundAwayFromZero(quotient, remainder, denominator, sign, mode) {
+		quotient.Add(quotient, big.NewInt(int64(sign)))
+	}
+	return quotient
+}
+
+func roundAwayFromZero(quotient, remainder, denominator *big.Int, sign int, mode RoundingMode) bool {
+	switch mode {
+	case RoundTruncate:
+		return false
+	case RoundFloor:
+		return sign < 0
+	case RoundCeiling:
+		return sign > 0
+	}
+	doubledRemainder := new(big.Int).Abs(remainder)
+	doubledRemainder.Lsh(doubledRemainder, 1)
+	halfComparison := doubledRemainder.Cmp(new(big.Int).Abs(denominator))
+	if halfComparison != 0 {
+		return halfComparison > 0
+	}
+	switch mode {
+	case RoundHalfDown:
+		return false
+	case RoundHalfEven:
+		return quotient.Bit(0) == 1
+	}
+	return true
+}
+
+// digits after the separator rounded to fractionLength digits, negated when the whole amount is negative
+// so that directed rounding modes round the amount rather than its magnitude
+func roundFractionString(fractionString string, fractionLength int64, negative bool, mode RoundingMode) (*big.Int, error) {
+	fraction, err := parseUnsignedBigInt(fractionString)
+	if err != nil {
+		return nil, err
+	}
+	if negative {
+		fraction.Neg(fraction)
+	}
+	digits := int64(len(fractionString))
+	if digits <= fractionLength {
+		return fraction.Mul(fraction, pow10Big(fractionLength-digits)), nil
+	}
+	return quoRound(fraction, pow10Big(digits-fractionLength), mode), nil
+}
@@ -0,0 +1,121 @@
+package assets
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNegativeFractions(t *testing.T) {
+	tests := []struct {
+		input         string
+		btcString     string
+		btcInt        int64
+		ethString     string
+		ethWei        string
+		usdString     string
+		usdInt        int64
+		usdPrettyText string
+	}{
+		{"-0.5", "-0.50000000", -50000000, "-0.500000000000000000", "-500000000000000000", "-0.50", -50, "-0.50"},
+		{"-.5", "-0.50000000", -50000000, "-0.500000000000000000", "-500000000000000000", "-0.50", -50, "-0.50"},
+		{"-0.01", "-0.01000000", -1000000, "-0.010000000000000000", "-10000000000000000", "-0.01", -1, "-0.01"},
+		{"-1.00000001", "-1.00000001", -100000001, "-1.000000010000000000", "-1000000010000000000", "-1.00", -100, "-1.00"},
+		{"-0", "0.00000000", 0, "0.000000000000000000", "0", "0.00", 0, "0.00"},
+		{"-0.000", "0.00000000", 0, "0.000000000000000000", "0", "0.00", 0, "0.00"},
+	}
+	for _, test := range tests {
+		btc, err := NewBitcoinFromString(test.input)
+		if err != nil || btc.GetStringValue() != test.btcString || btc.GetIntValue() != test.btcInt {
+			t.Errorf("Invalid bitcoin for %s: %v %v", test.input, btc, err)
+		}
+		eth, err := NewEtherFromString(test.input)
+		if err != nil || eth.GetStringValue() != test.ethString || eth.Wei().String() != test.ethWei {
+			t.Errorf("Invalid ether for %s: %v %v", test.input, eth, err)
+		}
+		usd, err := NewUSDFromString(test.input)
+		if err != nil || usd.GetStringValue() != test.usdString || usd.GetIntValue() != test.usdInt || usd.GetPrettyStringValue() != test.usdPrettyText {
+			t.Errorf("Invalid usd for %s: %v %v", test.input, usd, err)
+		}
+	}
+}
+
+func TestNegativeFractionRounding(t *testing.T) {
+	if usd, _ := NewUSDFromString("-34.50"); usd.GetIntValue() != -3450 {
+		t.Errorf("Invalid negative usd %d", usd.GetIntValue())
+	}
+	if floor, _ := NewBitcoinFromStringRounded("-0.123456781", RoundFloor); floor.GetIntValue() != -12345679 {
+		t.Errorf("Invalid floor rounding %d", floor.GetIntValue())
+	}
+	if ceiling, _ := NewUSDFromStringRounded("-2.349", RoundCeiling); ceiling.GetIntValue() != -234 {
+		t.Errorf("Invalid ceiling rounding %d", ceiling.GetIntValue())
+	}
+}
+
+func TestStringValueMatchesAmount(t *testing.T) {
+	btcCases := map[string]string{"-0.000000001": "0.00000000", "0.123456789": "0.12345679", "1.5": "1.50000000"}
+	for input, expected := range btcCases {
+		btc, err := NewBitcoinFromString(input)
+		if err != nil || btc.GetStringValue() != expected || fmt.Sprint(btc) != expected || btc.GetStringValue() != btc.Amount().GetStringValue() {
+			t.Errorf("Expected bitcoin %s for %s but got %v %v", expected, input, btc, err)
+		}
+	}
+	if usd, _ := NewUSDFromString("1.005"); usd.GetStringValue() != "1.01" || usd.GetIntValue() != 101 {
+		t.Errorf("Invalid usd string %s for %d", usd.GetStringValue(), usd.GetIntValue())
+	}
+	if euro, _ := NewFiatFromString(CurrencyEUR, "-2.345"); euro.GetStringValue() != "-2.35" || fmt.Sprintf("%v", euro) != "-2.35" {
+		t.Errorf("Invalid euro string %s", euro.GetStringValue())
+	}
+}
+
+func TestNegativeFromInt(t *testing.T) {
+	if btc := NewBitcoinFromInt(-1); btc.GetStringValue() != "-0.00000001" {
+		t.Errorf("Invalid bitcoin string %s", btc.GetStringValue())
+	}
+	if eth := NewEtherFromInt(-5000000); eth.GetStringValue() != "-0.000000000005000000" {
+		t.Errorf("Invalid ether string %s", eth.GetStringValue())
+	}
+	if usd := NewUSDFromInt(-105); usd.GetStringValue() != "-1.05" {
+		t.Errorf("Invalid usd string %s", usd.GetStringValue())
+	}
+}
+
+func TestNegativeRounding(t *testing.T) {
+	btc, _ := NewBitcoinFromString("-0.000000015")
+	if btc.GetIntValue() != -2 {
+		t.Errorf("Expected half up to round away from zero, got %d", btc.GetIntValue())
+	}
+	floor, _ := NewUSDFromStringRounded("-0.011", RoundFloor)
+	ceiling, _ := NewUSDFromStringRounded("-0.019", RoundCeiling)
+	if floor.GetIntValue() != -2 || ceiling.GetIntValue() != -1 {
+		t.Errorf("Invalid directed rounding %d %d", floor.GetIntValue(), ceiling.GetIntValue())
+	}
+	eth, _ := NewEtherFromString("-0.0000000000000000019")
+	if eth.Wei().Int64() != -1 {
+		t.Errorf("Expected ether to truncate toward zero, got %s", eth.Wei())
+	}
+}
+
+func TestSignHelpers(t *testing.T) {
+	btc, _ := NewBitcoinFromString("-1.5")
+	if btc.Sign() != -1 || !btc.IsNegative() || btc.IsZero() {
+		t.Errorf("Invalid sign for %s", btc.GetStringValue())
+	}
+	if btc.Abs().GetStringValue() != "1.50000000" || btc.Neg().GetIntValue() != 150000000 {
+		t.Errorf("Invalid abs %s or neg %d", btc.Abs().GetStringValue(), btc.Neg().GetIntValue())
+	}
+	eth, _ := NewEtherFromString("0.25")
+	if eth.Sign() != 1 || eth.IsNegative() || eth.Neg().GetStringValue() != "-0.250000000000000000" {
+		t.Errorf("Invalid sign helpers for %s", eth.GetStringValue())
+	}
+	if eth.Neg().Abs().Compare(eth) != 0 {
+		t.Errorf("Invalid abs of %s", eth.Neg().GetStringValue())
+	}
+	usd := NewUSDFromInt(0)
+	if usd.Sign() != 0 || !usd.IsZero() || usd.IsNegative() || usd.Neg().GetStringValue() != "0.00" {
+		t.Errorf("Invalid sign helpers for zero usd")
+	}
+	negativeUSD, _ := NewUSDFromString("-12.34")
+	if negativeUSD.Abs().GetStringValue() != "12.34" || !negativeUSD.Neg().Add(negativeUSD).IsZero() {
+		t.Errorf("Invalid abs %s", negativeUSD.Abs().GetStringValue())
+	}
+}
@@ -0,0 +1,214 @@
+package assets
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// Value stores a decimal string for NUMERIC columns, scan into a BitcoinValue to read it back
+func (bitcoin bitcoinStruct) Value() (driver.Value, error) {
+	return amountValue(bitcoin.amount, DecimalEncoding)
+}
+
+// Value stores a decimal string for NUMERIC columns, scan into an EtherValue to read it back
+func (ether etherStruct) Value() (driver.Value, error) {
+	return amountValue(ether.amount, DecimalEncoding)
+}
+
+// Value stores a decimal string for NUMERIC columns, scan into a USDValue to read it back
+func (usd usdStruct) Value() (driver.Value, error) {
+	return amountValue(usd.amount, DecimalEncoding)
+}
+
+// Value stores a decimal string for NUMERIC columns
+func (fiat fiatStruct) Value() (driver.Value, error) {
+	return amountValue(fiat.amount, DecimalEncoding)
+}
+
+// Value stores a decimal string for NUMERIC columns, or with MinorUnitEncoding the satoshis for BIGINT columns
+func (value BitcoinValue) Value() (driver.Value, error) {
+	if value.Bitcoin == nil {
+		return nil, ConversionError{message: "Cannot store a BitcoinValue without a Bitcoin, use NullBitcoin"}
+	}
+	return amountValue(value.Bitcoin.Amount(), value.Encoding)
+}
+
+// Scan reads a column written by Value
+func (value *BitcoinValue) Scan(src interface{}) error {
+	amount, err := scanAmount(CurrencyBTC, src, value.Encoding, RoundHalfUp)
+	if err != nil {
+		return err
+	}
+	bitcoin, err := NewBitcoinFromAmount(amount)
+	if err != nil {
+		return err
+	}
+	value.Bitcoin = bitcoin
+	return nil
+}
+
+// Value stores a decimal string for NUMERIC columns, or with MinorUnitEncoding the wei, as a BIGINT
+// when it fits in an int64 and otherwise as an integer string for NUMERIC columns
+func (value EtherValue) Value() (driver.Value, error) {
+	if value.Ether == nil {
+		return nil, ConversionError{message: "Cannot store an EtherValue without an Ether, use NullEther"}
+	}
+	return amountValue(value.Ether.Amount(), value.Encoding)
+}
+
+// Scan reads a column written by Value
+func (value *EtherValue) Scan(src interface{}) error {
+	amount, err := scanAmount(CurrencyETH, src, value.Encoding, RoundTruncate)
+	if err != nil {
+		return err
+	}
+	value.Ether = newEtherFromAmount(amount)
+	return nil
+}
+
+// Value stores a decimal string for NUMERIC columns, or with MinorUnitEncoding the cents for BIGINT columns
+func (value USDValue) Value() (driver.Value, error) {
+	if value.USD == nil {
+		return nil, ConversionError{message: "Cannot store a USDValue without a USD, use NullUSD"}
+	}
+	return amountValue(value.USD.Amount(), value.Encoding)
+}
+
+// Scan reads a column written by Value
+func (value *USDValue) Scan(src interface{}) error {
+	amount, err := scanAmount(CurrencyUSD, src, value.Encoding, RoundHalfUp)
+	if err != nil {
+		return err
+	}
+	usd, err := NewUSDFromAmount(amount)
+	if err != nil {
+		return err
+	}
+	value.USD = usd
+	return nil
+}
+
+// NullBitcoin a Bitcoin that may be NULL, Valid is true when it is not
+type NullBitcoin struct {
+	Bitcoin  Bitcoin
+	Valid    bool
+	Encoding Encoding
+}
+
+// Value stores NULL when not valid, otherwise like BitcoinValue
+func (value NullBitcoin) Value() (driver.Value, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+	return BitcoinValue{Bitcoin: value.Bitcoin, Encoding: value.Encoding}.Value()
+}
+
+// Scan reads NULL or a column written by Value
+func (value *NullBitcoin) Scan(src interface{}) error {
+	value.Bitcoin, value.Valid = nil, false
+	if src == nil {
+		return nil
+	}
+	scanned := BitcoinValue{Encoding: value.Encoding}
+	if err := scanned.Scan(src); err != nil {
+		return err
+	}
+	value.Bitcoin, value.Valid = scanned.Bitcoin, true
+	return nil
+}
+
+// NullEther an Ether that may be NULL, Valid is true when it is not
+type NullEther struct {
+	Ether    Ether
+	Valid    bool
+	Encoding Encoding
+}
+
+// Value stores NULL when not valid, otherwise like EtherValue
+func (value NullEther) Value() (driver.Value, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+	return EtherValue{Ether: value.Ether, Encoding: value.Encoding}.Value()
+}
+
+// Scan reads NULL or a column written by Value
+func (value *NullEther) Scan(src interface{}) error {
+	value.Ether, value.Valid = nil, false
+	if src == nil {
+		return nil
+	}
+	scanned := EtherValue{Encoding: value.Encoding}
+	if err := scanned.Scan(src); err != nil {
+		return err
+	}
+	value.Ether, value.Valid = scanned.Ether, true
+	return nil
+}
+
+// NullUSD a USD that may be NULL, Valid is true when it is not
+type NullUSD struct {
+	USD      USD
+	Valid    bool
+	Encoding Encoding
+}
+
+// Value stores NULL when not valid, otherwise like USDValue
+func (value NullUSD) Value() (driver.Value, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+	return USDValue{USD: value.USD, Encoding: value.Encoding}.Value()
+}
+
+// Scan reads NULL or a column written by Value
+func (value *NullUSD) Scan(src interface{}) error {
+	value.USD, value.Valid = nil, false
+	if src == nil {
+		return nil
+	}
+	scanned := USDValue{Encoding: value.Encoding}
+	if err := scanned.Scan(src); err != nil {
+		return err
+	}
+	value.USD, value.Valid = scanned.USD, true
+	return nil
+}
+
+func amountValue(amount Amount, encoding Encoding) (driver.Value, error) {
+	if encoding != MinorUnitEncoding {
+		return amount.GetStringValue(), nil
+	}
+	if amount.minorUnits().IsInt64() {
+		return amount.minorUnits().Int64(), nil
+	}
+	return amount.minorUnits().String(), nil
+}
+
+// integers and strings are minor units with MinorUnitEncoding, otherwise whole units and decimal strings
+// rounded with the mode the type's string constructor uses
+func scanAmount(currency Currency, src interface{}, encoding Encoding, mode RoundingMode) (Amount, error) {
+	var text string
+	switch typed := src.(type) {
+	case nil:
+		return Amount{}, ConversionError{message: "Cannot scan NULL into " + currency.Code + ", use a nullable type"}
+	case int64:
+		text = strconv.FormatInt(typed, 10)
+	case string:
+		text = typed
+	case []byte:
+		text = string(typed)
+	default:
+		return Amount{}, ConversionError{message: fmt.Sprintf("Cannot scan %T into %s", src, currency.Code)}
+	}
+	if encoding == MinorUnitEncoding {
+		minorUnits, ok := new(big.Int).SetString(text, 10)
+		if !ok {
+			return Amount{}, ConversionError{message: "Invalid " + currency.Code + " minor units [" + text + "]"}
+		}
+		return NewAmount(currency, minorUnits), nil
+	}
+	return ParseAmountRounded(currency, standardizeAmountString(text, currency.Decimals), mode)
+}
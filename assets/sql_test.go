@@ -0,0 +1,197 @@
+package assets
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeDriver keeps inserted rows in memory, every SELECT returns all of them
+type fakeDriver struct {
+	mutex sync.Mutex
+	rows  [][]driver.Value
+}
+
+type fakeConn struct {
+	driver *fakeDriver
+}
+
+type fakeStmt struct {
+	conn  fakeConn
+	query string
+}
+
+type fakeRows struct {
+	columns int
+	rows    [][]driver.Value
+	next    int
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return fakeConn{driver: d}, nil
+}
+
+func (conn fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeStmt{conn: conn, query: query}, nil
+}
+
+func (conn fakeConn) Close() error {
+	return nil
+}
+
+func (conn fakeConn) Begin() (driver.Tx, error) {
+	return nil, driver.ErrSkip
+}
+
+func (stmt fakeStmt) Close() error {
+	return nil
+}
+
+func (stmt fakeStmt) NumInput() int {
+	return -1
+}
+
+func (stmt fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	stmt.conn.driver.mutex.Lock()
+	defer stmt.conn.driver.mutex.Unlock()
+	stmt.conn.driver.rows = append(stmt.conn.driver.rows, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (stmt fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	stmt.conn.driver.mutex.Lock()
+	defer stmt.conn.driver.mutex.Unlock()
+	columns := strings.Count(stmt.query, ",") + 1
+	return &fakeRows{columns: columns, rows: stmt.conn.driver.rows}, nil
+}
+
+func (rows *fakeRows) Columns() []string {
+	return make([]string, rows.columns)
+}
+
+func (rows *fakeRows) Close() error {
+	return nil
+}
+
+func (rows *fakeRows) Next(dest []driver.Value) error {
+	if rows.next >= len(rows.rows) {
+		return io.EOF
+	}
+	copy(dest, rows.rows[rows.next])
+	rows.next++
+	return nil
+}
+
+var testDriver = &fakeDriver{}
+
+func init() {
+	sql.Register("assetsfake", testDriver)
+}
+
+func openFakeDB(t *testing.T) *sql.DB {
+	testDriver.mutex.Lock()
+	testDriver.rows = nil
+	testDriver.mutex.Unlock()
+	db, err := sql.Open("assetsfake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestSQLRoundTrip(t *testing.T) {
+	db := openFakeDB(t)
+	defer db.Close()
+	btc, _ := NewBitcoinFromString("1.5")
+	eth, _ := NewEtherFromString("12.000000000000000001")
+	usd := NewUSDFromInt(-4210)
+	_, err := db.Exec("INSERT INTO balances VALUES (?, ?, ?, ?, ?)",
+		BitcoinValue{Bitcoin: btc},
+		EtherValue{Ether: eth, Encoding: MinorUnitEncoding},
+		USDValue{USD: usd, Encoding: MinorUnitEncoding},
+		NullBitcoin{},
+		NullUSD{USD: usd, Valid: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored := testDriver.rows[0]
+	if stored[0] != "1.50000000" || stored[1] != "12000000000000000001" || stored[2] != int64(-4210) || stored[3] != nil || stored[4] != "-42.10" {
+		t.Errorf("Invalid stored values %#v", stored)
+	}
+	var scannedBtc BitcoinValue
+	scannedEth := EtherValue{Encoding: MinorUnitEncoding}
+	scannedUsd := USDValue{Encoding: MinorUnitEncoding}
+	var nullBtc NullBitcoin
+	var nullUsd NullUSD
+	err = db.QueryRow("SELECT btc, eth, usd, null_btc, null_usd FROM balances").Scan(&scannedBtc, &scannedEth, &scannedUsd, &nullBtc, &nullUsd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scannedBtc.Compare(btc) != 0 || scannedEth.Compare(eth) != 0 || scannedUsd.Compare(usd) != 0 {
+		t.Errorf("Invalid scanned values %v %v %v", scannedBtc.Bitcoin, scannedEth.Ether, scannedUsd.USD)
+	}
+	if nullBtc.Valid || nullBtc.Bitcoin != nil {
+		t.Errorf("Expected NULL bitcoin but got %v", nullBtc.Bitcoin)
+	}
+	if !nullUsd.Valid || nullUsd.USD.Compare(usd) != 0 {
+		t.Errorf("Invalid nullable usd %v", nullUsd.USD)
+	}
+}
+
+func TestSQLBareValues(t *testing.T) {
+	db := openFakeDB(t)
+	defer db.Close()
+	btc, _ := NewBitcoinFromString("1.5")
+	eth, _ := NewEtherFromString("12.000000000000000001")
+	usd := NewUSDFromInt(-4210)
+	if _, err := db.Exec("INSERT INTO balances VALUES (?, ?, ?)", btc, eth, usd); err != nil {
+		t.Fatal(err)
+	}
+	stored := testDriver.rows[0]
+	if stored[0] != "1.50000000" || stored[1] != "12.000000000000000001" || stored[2] != "-42.10" {
+		t.Errorf("Invalid stored values %#v", stored)
+	}
+	var scannedBtc BitcoinValue
+	var scannedEth EtherValue
+	var scannedUsd USDValue
+	if err := db.QueryRow("SELECT btc, eth, usd FROM balances").Scan(&scannedBtc, &scannedEth, &scannedUsd); err != nil {
+		t.Fatal(err)
+	}
+	if scannedBtc.Compare(btc) != 0 || scannedEth.Compare(eth) != 0 || scannedUsd.Compare(usd) != 0 {
+		t.Errorf("Invalid scanned values %v %v %v", scannedBtc.Bitcoin, scannedEth.Ether, scannedUsd.USD)
+	}
+}
+
+func TestSQLScanRoundsLikeConstructors(t *testing.T) {
+	var scanned EtherValue
+	if err := scanned.Scan([]byte("0.0000000000000000019")); err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := NewEtherFromString("0.0000000000000000019")
+	if scanned.Wei().Cmp(expected.Wei()) != 0 {
+		t.Errorf("Expected %s wei but got %s", expected.Wei(), scanned.Wei())
+	}
+}
+
+func TestSQLScanErrors(t *testing.T) {
+	var btc BitcoinValue
+	if err := btc.Scan(nil); err == nil {
+		t.Error("Expected error scanning NULL into BitcoinValue")
+	}
+	if err := btc.Scan(1.5); err == nil {
+		t.Error("Expected error scanning a float")
+	}
+	if err := btc.Scan([]byte("1.25")); err != nil || btc.GetIntValue() != 125000000 {
+		t.Errorf("Invalid bytes scan %v %v", btc.Bitcoin, err)
+	}
+	if _, err := (BitcoinValue{}).Value(); err == nil {
+		t.Error("Expected error storing an empty BitcoinValue")
+	}
+	minor := USDValue{Encoding: MinorUnitEncoding}
+	if err := minor.Scan("12.5"); err == nil {
+		t.Error("Expected error scanning a fractional minor unit string")
+	}
+}
@@ -0,0 +1,166 @@
+package assets
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ParseErrorKind why a strict parse failed
+type ParseErrorKind int
+
+const (
+	// ParseErrorEmpty no input
+	ParseErrorEmpty ParseErrorKind = iota + 1
+	// ParseErrorLeadingPlus input starts with '+'
+	ParseErrorLeadingPlus
+	// ParseErrorWhitespace input contains a space, tab or other whitespace
+	ParseErrorWhitespace
+	// ParseErrorMultipleSeparators input contains more than one decimal separator
+	ParseErrorMultipleSeparators
+	// ParseErrorInvalidCharacter input contains something other than a sign, digits and a separator
+	ParseErrorInvalidCharacter
+	// ParseErrorMissingDigits no digits before or after the separator
+	ParseErrorMissingDigits
+	// ParseErrorExcessPrecision more fraction digits than the currency holds
+	ParseErrorExcessPrecision
+	// ParseErrorInvalidGrouping group separators in the wrong place for the locale
+	ParseErrorInvalidGrouping
+)
+
+var parseErrorKindNames = map[ParseErrorKind]string{
+	ParseErrorEmpty:              "empty input",
+	ParseErrorLeadingPlus:        "leading plus sign",
+	ParseErrorWhitespace:         "whitespace",
+	ParseErrorMultipleSeparators: "multiple separators",
+	ParseErrorInvalidCharacter:   "invalid character",
+	ParseErrorMissingDigits:      "missing digits",
+	ParseErrorExcessPrecision:    "excess precision",
+	ParseErrorInvalidGrouping:    "invalid grouping",
+}
+
+func (kind ParseErrorKind) String() string {
+	if name, ok := parseErrorKindNames[kind]; ok {
+		return name
+	}
+	return "unknown parse error " + strconv.Itoa(int(kind))
+}
+
+// ParseError strict parse failure, Position is the byte offset of the offending character in Input
+type ParseError struct {
+	Kind     ParseErrorKind
+	Currency string
+	Input    string
+	Position int
+}
+
+// sentinels for use with errors.Is, any ParseError of the same kind matches
+var (
+	ErrEmpty              = ParseError{Kind: ParseErrorEmpty}
+	ErrLeadingPlus        = ParseError{Kind: ParseErrorLeadingPlus}
+	ErrWhitespace         = ParseError{Kind: ParseErrorWhitespace}
+	ErrMultipleSeparators = ParseError{Kind: ParseErrorMultipleSeparators}
+	ErrInvalidCharacter   = ParseError{Kind: ParseErrorInvalidCharacter}
+	ErrMissingDigits      = ParseError{Kind: ParseErrorMissingDigits}
+	ErrExcessPrecision    = ParseError{Kind: ParseErrorExcessPrecision}
+	ErrInvalidGrouping    = ParseError{Kind: ParseErrorInvalidGrouping}
+)
+
+func (err ParseError) Error() string {
+	return "Error parsing " + err.Currency + " [" + err.Input + "] -- " + err.Kind.String() + " at position " + strconv.Itoa(err.Position)
+}
+
+// Is matches a ParseError of the same kind
+func (err ParseError) Is(target error) bool {
+	targetErr, ok := target.(ParseError)
+	return ok && targetErr.Kind == err.Kind
+}
+
+// ParseAmountStrict create an amount from a decimal string, rejecting anything other than
+// an optional '-', digits and at most one separator followed by no more than the currency's decimals
+func ParseAmountStrict(currency Currency, amountString string) (Amount, error) {
+	if err := validateStrictDecimal(currency, amountString); err != nil {
+		return Amount{}, err
+	}
+	return ParseAmountRounded(currency, amountString, RoundTruncate)
+}
+
+// NewBitcoinFromStringStrict create new bitcoin from a string, rejecting malformed input and fractions of a satoshi
+func NewBitcoinFromStringStrict(btcString string) (Bitcoin, error) {
+	if err := validateStrictDecimal(CurrencyBTC, btcString); err != nil {
+		return nil, err
+	}
+	return NewBitcoinFromStringRounded(btcString, RoundTruncate)
+}
+
+// NewEtherFromStringStrict create new ether from a string, rejecting malformed input and fractions of a wei
+func NewEtherFromStringStrict(ethString string) (Ether, error) {
+	if err := validateStrictDecimal(CurrencyETH, ethString); err != nil {
+		return nil, err
+	}
+	return NewEtherFromStringRounded(ethString, RoundTruncate)
+}
+
+// NewUSDFromStringStrict create USD from a string, rejecting malformed input and fractions of a cent
+func NewUSDFromStringStrict(usdString string) (USD, error) {
+	if err := validateStrictDecimal(CurrencyUSD, usdString); err != nil {
+		return nil, err
+	}
+	return NewUSDFromStringRounded(usdString, RoundTruncate)
+}
+
+// NewTokenFromStringStrict create new token from a string, rejecting malformed input and fractions of a base unit
+func NewTokenFromStringStrict(descriptor TokenDescriptor, tokenString string) (Token, error) {
+	if err := validateStrictDecimal(descriptor.Currency(), tokenString); err != nil {
+		return nil, err
+	}
+	return NewTokenFromStringRounded(descriptor, tokenString, RoundTruncate)
+}
+
+func validateStrictDecimal(currency Currency, amountString string) error {
+	newError := func(kind ParseErrorKind, position int) error {
+		return ParseError{Kind: kind, Currency: currency.Code, Input: amountString, Position: position}
+	}
+	if amountString == "" {
+		return newError(ParseErrorEmpty, 0)
+	}
+	if strings.HasPrefix(amountString, "+") {
+		return newError(ParseErrorLeadingPlus, 0)
+	}
+	separatorPosition := -1
+	wholeDigits := 0
+	fractionDigits := 0
+	for position, character := range amountString {
+		switch {
+		case character >= '0' && character <= '9':
+			if separatorPosition < 0 {
+				wholeDigits++
+				continue
+			}
+			fractionDigits++
+			if int64(fractionDigits) > currency.Decimals {
+				return newError(ParseErrorExcessPrecision, position)
+			}
+		case character == '-' && position == 0:
+		case string(character) == amountSeparator:
+			if separatorPosition >= 0 {
+				return newError(ParseErrorMultipleSeparators, position)
+			}
+			separatorPosition = position
+		case unicode.IsSpace(character):
+			return newError(ParseErrorWhitespace, position)
+		default:
+			return newError(ParseErrorInvalidCharacter, position)
+		}
+	}
+	if wholeDigits == 0 {
+		if separatorPosition >= 0 {
+			return newError(ParseErrorMissingDigits, separatorPosition)
+		}
+		return newError(ParseErrorMissingDigits, len(amountString))
+	}
+	if separatorPosition >= 0 && fractionDigits == 0 {
+		return newError(ParseErrorMissingDigits, separatorPosition+1)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package assets
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStrictParsing(t *testing.T) {
+	btc, err := NewBitcoinFromStringStrict("12.3456789")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if btc.GetIntValue() != 1234567890 {
+		t.Errorf("Invalid strict bitcoin %d", btc.GetIntValue())
+	}
+	usd, err := NewUSDFromStringStrict("1234")
+	if err != nil || usd.GetStringValue() != "1234.00" {
+		t.Errorf("Invalid strict usd %v %v", usd, err)
+	}
+}
+
+func TestStrictParseErrors(t *testing.T) {
+	cases := []struct {
+		input    string
+		sentinel error
+		position int
+	}{
+		{"", ErrEmpty, 0},
+		{"+1.00", ErrLeadingPlus, 0},
+		{"1 000.00", ErrWhitespace, 1},
+		{" 1.00", ErrWhitespace, 0},
+		{"1.2.3", ErrMultipleSeparators, 3},
+		{"1,00", ErrInvalidCharacter, 1},
+		{"1-2", ErrInvalidCharacter, 1},
+		{".50", ErrMissingDigits, 0},
+		{"-", ErrMissingDigits, 1},
+		{"1.", ErrMissingDigits, 2},
+		{"1.005", ErrExcessPrecision, 4},
+	}
+	for _, c := range cases {
+		_, err := NewUSDFromStringStrict(c.input)
+		if !errors.Is(err, c.sentinel) {
+			t.Errorf("Expected %v for [%s] but got %v", c.sentinel, c.input, err)
+			continue
+		}
+		var parseErr ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("Expected ParseError for [%s]", c.input)
+			continue
+		}
+		if parseErr.Input != c.input || parseErr.Position != c.position || parseErr.Currency != "USD" {
+			t.Errorf("Invalid parse error for [%s] %+v", c.input, parseErr)
+		}
+	}
+	if _, err := NewEtherFromStringStrict("1.0000000000000000001"); !errors.Is(err, ErrExcessPrecision) {
+		t.Errorf("Expected excess precision for ether but got %v", err)
+	}
+	if _, err := NewUSDFromStringStrict("1.2.3"); errors.Is(err, ErrExcessPrecision) {
+		t.Error("Expected multiple separators not to match excess precision")
+	}
+}
@@ -0,0 +1,167 @@
+package assets
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const maxTokenDecimals = 255
+
+// TokenDescriptor describes an erc-20 style token
+type TokenDescriptor struct {
+	Address  string `json:"address"`
+	Symbol   string `json:"symbol"`
+	Decimals int64  `json:"decimals"`
+}
+
+// Currency the currency token amounts are denominated in
+func (descriptor TokenDescriptor) Currency() Currency {
+	return Currency{Code: descriptor.Symbol, Symbol: descriptor.Symbol, Decimals: descriptor.Decimals, DisplayDecimals: descriptor.Decimals}
+}
+
+func (descriptor TokenDescriptor) validate() error {
+	if descriptor.Symbol == "" {
+		return CurrencyError{message: "Token symbol is required for address [" + descriptor.Address + "]"}
+	}
+	if !isTokenAddress(descriptor.Address) {
+		return CurrencyError{message: "Invalid address [" + descriptor.Address + "] for token [" + descriptor.Symbol + "]"}
+	}
+	if descriptor.Decimals < 0 || descriptor.Decimals > maxTokenDecimals {
+		return CurrencyError{message: "Invalid decimals [" + strconv.FormatInt(descriptor.Decimals, 10) + "] for token [" + descriptor.Symbol + "]"}
+	}
+	return nil
+}
+
+// 0x followed by 40 hex digits, in any case
+func isTokenAddress(address string) bool {
+	if len(address) != 42 || !strings.HasPrefix(address, "0x") {
+		return false
+	}
+	for _, digit := range strings.ToLower(address[2:]) {
+		if (digit < '0' || digit > '9') && (digit < 'a' || digit > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func sameToken(descriptor, other TokenDescriptor) bool {
+	return strings.EqualFold(descriptor.Address, other.Address) && descriptor.Decimals == other.Decimals
+}
+
+func (token tokenStruct) GetStringValue() string {
+	return token.stringValue
+}
+
+// GetIntValue returns the value in base units, saturating at the int64 limits, use BaseUnits for the exact value
+func (token tokenStruct) GetIntValue() int64 {
+	return token.amount.GetIntValue()
+}
+
+// BaseUnits returns the value in the token's smallest unit
+func (token tokenStruct) BaseUnits() *big.Int {
+	return token.amount.MinorUnits()
+}
+
+func (token tokenStruct) bigIntValue() *big.Int {
+	return token.BaseUnits()
+}
+
+func (token tokenStruct) Descriptor() TokenDescriptor {
+	return token.descriptor
+}
+
+func (token tokenStruct) Amount() Amount {
+	return token.amount
+}
+
+func (token tokenStruct) Add(tokenToAdd Token) (Token, error) {
+	if err := token.checkToken("add", tokenToAdd); err != nil {
+		return nil, err
+	}
+	return newTokenFromAmount(token.descriptor, token.amount.add(tokenToAdd.Amount())), nil
+}
+
+func (token tokenStruct) Subtract(tokenToSubtract Token) (Token, error) {
+	if err := token.checkToken("subtract", tokenToSubtract); err != nil {
+		return nil, err
+	}
+	return newTokenFromAmount(token.descriptor, token.amount.subtract(tokenToSubtract.Amount())), nil
+}
+
+// Compare sort by amount ascending
+func (token tokenStruct) Compare(other Token) (int, error) {
+	if err := token.checkToken("compare", other); err != nil {
+		return 0, err
+	}
+	return token.amount.compare(other.Amount()), nil
+}
+
+func (token tokenStruct) GetCost(price USD) USD {
+	return token.GetCostRounded(price, RoundTruncate)
+}
+
+// GetCostRounded get cost, rounding fractions of a cent with the rounding mode
+func (token tokenStruct) GetCostRounded(price USD, mode RoundingMode) USD {
+	return newUSDFromAmount(token.amount.GetCostRounded(price.Amount(), mode))
+}
+
+// GetCostChecked get cost, returning an OverflowError if the cost does not fit in USD
+func (token tokenStruct) GetCostChecked(price USD) (USD, error) {
+	return newUSDFromAmountChecked(token.amount.GetCostRounded(price.Amount(), RoundTruncate), token.descriptor.Symbol+" cost")
+}
+
+func (token tokenStruct) Multiply(value int64, fractionLength int64) Token {
+	return token.MultiplyRounded(value, fractionLength, RoundTruncate)
+}
+
+// MultiplyRounded multiply, rounding fractions of a base unit with the rounding mode
+func (token tokenStruct) MultiplyRounded(value int64, fractionLength int64, mode RoundingMode) Token {
+	return newTokenFromAmount(token.descriptor, token.amount.MultiplyRounded(value, fractionLength, mode))
+}
+
+func (token tokenStruct) GetFractionLength() int64 {
+	return token.descriptor.Decimals
+}
+
+func (token tokenStruct) checkToken(operation string, other Token) error {
+	if !sameToken(token.descriptor, other.Descriptor()) {
+		return CurrencyError{message: "Token mismatch in " + operation + " -- expected [" + token.descriptor.Symbol + " " + token.descriptor.Address + "] but got [" + other.Descriptor().Symbol + " " + other.Descriptor().Address + "]"}
+	}
+	return nil
+}
+
+// NewTokenFromString create new token based on string value, truncating fractions of a base unit
+func NewTokenFromString(descriptor TokenDescriptor, tokenString string) (Token, error) {
+	return NewTokenFromStringRounded(descriptor, tokenString, RoundTruncate)
+}
+
+// NewTokenFromStringRounded create new token based on string value, rounding fractions of a base unit with the rounding mode
+func NewTokenFromStringRounded(descriptor TokenDescriptor, tokenString string, mode RoundingMode) (Token, error) {
+	if err := descriptor.validate(); err != nil {
+		return nil, err
+	}
+	amount, err := ParseAmountRounded(descriptor.Currency(), standardizeAmountString(tokenString, descriptor.Decimals), mode)
+	if err != nil {
+		return nil, err
+	}
+	return newTokenFromAmount(descriptor, amount), nil
+}
+
+// NewTokenFromInt create a new token based on int value in base units
+func NewTokenFromInt(descriptor TokenDescriptor, baseUnits int64) (Token, error) {
+	return NewTokenFromBaseUnits(descriptor, big.NewInt(baseUnits))
+}
+
+// NewTokenFromBaseUnits create a new token from a value in base units
+func NewTokenFromBaseUnits(descriptor TokenDescriptor, baseUnits *big.Int) (Token, error) {
+	if err := descriptor.validate(); err != nil {
+		return nil, err
+	}
+	return newTokenFromAmount(descriptor, NewAmount(descriptor.Currency(), baseUnits)), nil
+}
+
+func newTokenFromAmount(descriptor TokenDescriptor, amount Amount) Token {
+	return tokenStruct{descriptor: descriptor, stringValue: amount.GetStringValue(), amount: amount}
+}
@@ -0,0 +1,96 @@
+package assets
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// TokenRegistry known tokens, looked up by symbol or contract address
+type TokenRegistry struct {
+	mutex     sync.RWMutex
+	bySymbol  map[string]TokenDescriptor
+	byAddress map[string]TokenDescriptor
+}
+
+// NewTokenRegistry create a registry holding the descriptors
+func NewTokenRegistry(descriptors ...TokenDescriptor) (*TokenRegistry, error) {
+	registry := &TokenRegistry{bySymbol: map[string]TokenDescriptor{}, byAddress: map[string]TokenDescriptor{}}
+	for _, descriptor := range descriptors {
+		if err := registry.Register(descriptor); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+// LoadTokenRegistry create a registry from a JSON array of token descriptors
+func LoadTokenRegistry(reader io.Reader) (*TokenRegistry, error) {
+	var descriptors []TokenDescriptor
+	if err := json.NewDecoder(reader).Decode(&descriptors); err != nil {
+		return nil, err
+	}
+	return NewTokenRegistry(descriptors...)
+}
+
+// LoadTokenRegistryFile create a registry from a JSON file of token descriptors
+func LoadTokenRegistryFile(path string) (*TokenRegistry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return LoadTokenRegistry(file)
+}
+
+// Register add a token, symbols and addresses must be unique within the registry
+func (registry *TokenRegistry) Register(descriptor TokenDescriptor) error {
+	if err := descriptor.validate(); err != nil {
+		return err
+	}
+	address := strings.ToLower(descriptor.Address)
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	if existing, ok := registry.bySymbol[descriptor.Symbol]; ok && !sameToken(existing, descriptor) {
+		return CurrencyError{message: "Token symbol [" + descriptor.Symbol + "] is already registered to [" + existing.Address + "]"}
+	}
+	if existing, ok := registry.byAddress[address]; ok && existing.Symbol != descriptor.Symbol {
+		return CurrencyError{message: "Token address [" + descriptor.Address + "] is already registered as [" + existing.Symbol + "]"}
+	}
+	registry.bySymbol[descriptor.Symbol] = descriptor
+	registry.byAddress[address] = descriptor
+	return nil
+}
+
+// LookupSymbol find a token by symbol
+func (registry *TokenRegistry) LookupSymbol(symbol string) (TokenDescriptor, bool) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	descriptor, ok := registry.bySymbol[symbol]
+	return descriptor, ok
+}
+
+// LookupAddress find a token by contract address, ignoring case
+func (registry *TokenRegistry) LookupAddress(address string) (TokenDescriptor, bool) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	descriptor, ok := registry.byAddress[strings.ToLower(address)]
+	return descriptor, ok
+}
+
+// Tokens all registered tokens, sorted by symbol
+func (registry *TokenRegistry) Tokens() []TokenDescriptor {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	descriptors := make([]TokenDescriptor, 0, len(registry.bySymbol))
+	for _, descriptor := range registry.bySymbol {
+		descriptors = append(descriptors, descriptor)
+	}
+	sort.Slice(descriptors, func(i, j int) bool {
+		return descriptors[i].Symbol < descriptors[j].Symbol
+	})
+	return descriptors
+}
@@ -0,0 +1,75 @@
+package assets
+
+import (
+	"strings"
+	"testing"
+)
+
+func loadTestTokens(t *testing.T) *TokenRegistry {
+	registry, err := LoadTokenRegistryFile("testdata/tokens.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return registry
+}
+
+func TestTokenRegistry(t *testing.T) {
+	registry := loadTestTokens(t)
+	usdc, ok := registry.LookupSymbol("USDC")
+	if !ok || usdc.Decimals != 6 {
+		t.Errorf("Invalid USDC descriptor %v", usdc)
+	}
+	dai, ok := registry.LookupAddress("0x6b175474e89094c44da98b954eedeac495271d0f")
+	if !ok || dai.Symbol != "DAI" || dai.Decimals != 18 {
+		t.Errorf("Invalid DAI descriptor %v", dai)
+	}
+	if len(registry.Tokens()) != 3 || registry.Tokens()[0].Symbol != "DAI" {
+		t.Errorf("Invalid registered tokens %v", registry.Tokens())
+	}
+	if err := registry.Register(TokenDescriptor{Address: "0x0000000000000000000000000000000000000001", Symbol: "USDC", Decimals: 6}); err == nil {
+		t.Error("Expected error registering a second USDC address")
+	}
+	if _, err := LoadTokenRegistry(strings.NewReader(`[{"address": "0x12", "symbol": "BAD", "decimals": 6}]`)); err == nil {
+		t.Error("Expected error loading a token with an invalid address")
+	}
+}
+
+func TestTokenArithmetic(t *testing.T) {
+	registry := loadTestTokens(t)
+	usdc, _ := registry.LookupSymbol("USDC")
+	dai, _ := registry.LookupSymbol("DAI")
+	payment, err := NewTokenFromString(usdc, "1250.1234567")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if payment.GetStringValue() != "1250.123456" || payment.GetIntValue() != 1250123456 {
+		t.Errorf("Invalid USDC value %s", payment.GetStringValue())
+	}
+	fee, _ := NewTokenFromInt(usdc, 123456)
+	net, err := payment.Subtract(fee)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if net.GetStringValue() != "1250.000000" {
+		t.Errorf("Invalid net value %s", net.GetStringValue())
+	}
+	if comparison, _ := net.Compare(payment); comparison != -1 {
+		t.Errorf("Expected net to be less than payment")
+	}
+	if net.Multiply(5, 1).GetStringValue() != "625.000000" {
+		t.Errorf("Invalid multiply %s", net.Multiply(5, 1).GetStringValue())
+	}
+	daiAmount, _ := NewTokenFromString(dai, "1.000000000000000001")
+	if _, err := payment.Add(daiAmount); err == nil {
+		t.Error("Expected error adding DAI to USDC")
+	}
+	price, _ := NewUSDFromString("1.00")
+	if net.GetCost(price).GetStringValue() != "1250.00" {
+		t.Errorf("Invalid cost %s", net.GetCost(price).GetStringValue())
+	}
+	if daiAmount.BaseUnits().String() != "1000000000000000001" {
+		t.Errorf("Invalid DAI base units %s", daiAmount.BaseUnits().String())
+	}
+}
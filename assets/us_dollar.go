@@ -1,30 +1,37 @@
 package assets
 
-import (
-	"bytes"
-	"math"
-	"strconv"
-	"strings"
-	"unicode/utf8"
-)
-
-const (
-	usdSizeSeparator                   = "."
-	usdStringFractionLength      int64 = 2
-	usdIntFractionLength         int64 = 2
-	usdFractionSignificantDigits int64 = 2
-)
-
-var usdCoinMultiplier = int64(math.Pow10(int(usdIntFractionLength)))
+import "math/big"
+
+const usdIntFractionLength int64 = 2
 
 // Compare compare usd ascending
 func (usd usdStruct) Compare(other USD) int {
-	if usd.GetIntValue() < other.GetIntValue() {
-		return -1
-	} else if usd.GetIntValue() > other.GetIntValue() {
-		return 1
-	}
-	return 0
+	return usd.amount.compare(other.Amount())
+}
+
+// Abs the usd without its sign, the most negative usd saturates at the largest
+func (usd usdStruct) Abs() USD {
+	return newUSDFromAmount(usd.amount.Abs())
+}
+
+// Neg the usd with its sign flipped, the most negative usd saturates at the largest
+func (usd usdStruct) Neg() USD {
+	return newUSDFromAmount(usd.amount.Neg())
+}
+
+// Sign -1, 0 or 1 for negative, zero and positive usd
+func (usd usdStruct) Sign() int {
+	return usd.amount.Sign()
+}
+
+// IsZero true when the usd is zero
+func (usd usdStruct) IsZero() bool {
+	return usd.amount.IsZero()
+}
+
+// IsNegative true when the usd is less than zero
+func (usd usdStruct) IsNegative() bool {
+	return usd.amount.IsNegative()
 }
 
 func (usd usdStruct) GetFractionLength() int64 {
@@ -33,18 +40,41 @@ func (usd usdStruct) GetFractionLength() int64 {
 
 // NewUSDFromString create USD from string
 func NewUSDFromString(usdString string) (USD, error) {
-	usdString = standardizeUsdString(usdString)
-	usdInt, err := convertUsdStringToInt(usdString)
+	return NewUSDFromStringRounded(usdString, RoundHalfUp)
+}
+
+// NewUSDFromStringRounded create USD from string, rounding fractions of a cent with the rounding mode
+func NewUSDFromStringRounded(usdString string, mode RoundingMode) (USD, error) {
+	amount, err := parseFiatString(CurrencyUSD, usdString, mode, "usd conversion")
 	if err != nil {
 		return nil, err
 	}
-	return usdStruct{stringValue: usdString, intValue: usdInt}, nil
+	return newUSDFromAmount(amount), nil
 }
 
 // NewUSDFromInt create USD from int value
 func NewUSDFromInt(usdInt int64) USD {
-	usdString := convertUsdIntToString(usdInt, usdStringFractionLength)
-	return usdStruct{stringValue: usdString, intValue: usdInt}
+	return newUSDFromAmount(NewAmountFromInt(CurrencyUSD, usdInt))
+}
+
+// NewUSDFromAmount create USD from an amount denominated in USD
+func NewUSDFromAmount(amount Amount) (USD, error) {
+	if err := checkCurrencyDescriptor("usd conversion", CurrencyUSD, amount.Currency()); err != nil {
+		return nil, err
+	}
+	return newUSDFromAmountChecked(amount, "usd conversion")
+}
+
+func newUSDFromAmount(amount Amount) USD {
+	amount = saturateInt64(amount)
+	return usdStruct{stringValue: amount.GetStringValue(), amount: amount}
+}
+
+func newUSDFromAmountChecked(amount Amount, operation string) (USD, error) {
+	if _, err := bigToInt64Checked(amount.minorUnits(), operation); err != nil {
+		return nil, err
+	}
+	return newUSDFromAmount(amount), nil
 }
 
 func (usd usdStruct) GetStringValue() string {
@@ -52,132 +82,56 @@ func (usd usdStruct) GetStringValue() string {
 }
 
 func (usd usdStruct) GetPrettyStringValue() string {
-	return convertUsdIntToString(usd.intValue, usdIntFractionLength)
+	return usd.amount.GetPrettyStringValue()
 }
 
 func (usd usdStruct) GetIntValue() int64 {
-	return usd.intValue
+	return usd.amount.GetIntValue()
 }
 
+func (usd usdStruct) Amount() Amount {
+	return usd.amount
+}
+
+// fromAmount a usd holding the amount, sealing Quantity to the types in this package
+func (usd usdStruct) fromAmount(amount Amount) USD {
+	return newUSDFromAmount(amount)
+}
+
+func (usd usdStruct) bigIntValue() *big.Int {
+	return usd.amount.bigIntValue()
+}
+
+// Add add, saturating at the int64 limits, use AddChecked to detect overflow
 func (usd usdStruct) Add(usdToAdd USD) USD {
-	usdInt := usd.GetIntValue()
-	usdInt += usdToAdd.GetIntValue()
-	return NewUSDFromInt(usdInt)
+	return newUSDFromAmount(usd.amount.add(usdToAdd.Amount()))
 }
 
-func (usd usdStruct) Subtract(usdToSubtract USD) USD {
-	usdInt := usd.GetIntValue()
-	usdInt -= usdToSubtract.GetIntValue()
-	return NewUSDFromInt(usdInt)
+// AddChecked add, returning an OverflowError instead of saturating
+func (usd usdStruct) AddChecked(usdToAdd USD) (USD, error) {
+	return newUSDFromAmountChecked(usd.amount.add(usdToAdd.Amount()), "usd add")
 }
 
-func (usd usdStruct) Multiply(value int64, fractionLength int64) USD {
-	multipliedValue := usd.GetIntValue() * value
-	multipliedFractionLength := usdFractionSignificantDigits + fractionLength
-	usdValue := roundUsd(multipliedValue, multipliedFractionLength, usdFractionSignificantDigits)
-	return NewUSDFromInt(usdValue)
-}
-
-func convertUsdStringToInt(usdString string) (int64, error) {
-	pieces := strings.Split(usdString, usdSizeSeparator)
-	cents := int64(0)
-	if len(pieces) > 1 {
-		var err error
-		cents, err = convertUsdFractionStringToInt(pieces[1])
-		if err != nil {
-			return 0, ConversionError{message: "Error converting fraction usd [" + pieces[1] + "] for string [" + usdString + "] -- [" + err.Error() + "]"}
-		}
-	}
-	dollars, err := convertWholeUsdStringToInt(pieces)
-	if err != nil {
-		return 0, ConversionError{message: "Error converting whole usd [" + pieces[0] + "] -- [" + err.Error() + "]"}
-	}
-	usdRepresentation := dollars + cents
-	return int64(usdRepresentation), nil
+// Subtract subtract, saturating at the int64 limits, use SubtractChecked to detect overflow
+func (usd usdStruct) Subtract(usdToSubtract USD) USD {
+	return newUSDFromAmount(usd.amount.subtract(usdToSubtract.Amount()))
 }
 
-func standardizeUsdString(usdString string) string {
-	pieces := strings.Split(usdString, usdSizeSeparator)
-	fractionString := "0"
-	if len(pieces) > 1 {
-		fractionString = pieces[1]
-	}
-	fractionLength := int64(utf8.RuneCountInString(fractionString))
-	var fractionBuffer bytes.Buffer
-	fractionBuffer.WriteString(fractionString)
-	for i := fractionLength; i < usdStringFractionLength; i++ {
-		fractionBuffer.WriteString("0")
-	}
-	var standardizedBuffer bytes.Buffer
-	standardizedBuffer.WriteString(pieces[0])
-	standardizedBuffer.WriteString(usdSizeSeparator)
-	standardizedBuffer.WriteString(fractionBuffer.String())
-	return standardizedBuffer.String()
+// SubtractChecked subtract, returning an OverflowError instead of saturating
+func (usd usdStruct) SubtractChecked(usdToSubtract USD) (USD, error) {
+	return newUSDFromAmountChecked(usd.amount.subtract(usdToSubtract.Amount()), "usd subtract")
 }
 
-func convertUsdFractionStringToInt(fractionString string) (int64, error) {
-	fraction, err := strconv.Atoi(fractionString)
-	if err != nil {
-		return 0, err
-	}
-	unrounded := int64(fraction)
-	return roundUsdFromStringRepresentation(unrounded, len(fractionString)), nil
+func (usd usdStruct) Multiply(value int64, fractionLength int64) USD {
+	return usd.MultiplyRounded(value, fractionLength, RoundHalfUp)
 }
 
-func convertWholeUsdStringToInt(pieces []string) (int64, error) {
-	dollar, err := strconv.Atoi(pieces[0])
-	if err != nil {
-		return 0, err
-	}
-	dollar = dollar * int(usdCoinMultiplier)
-	return int64(dollar), nil
+// MultiplyRounded multiply, rounding fractions of a cent with the rounding mode
+func (usd usdStruct) MultiplyRounded(value int64, fractionLength int64, mode RoundingMode) USD {
+	return newUSDFromAmount(usd.amount.MultiplyRounded(value, fractionLength, mode))
 }
 
-func convertUsdIntToString(usdInt int64, fractionsToPrint int64) string {
-	negative := usdInt < 0
-	if negative {
-		usdInt = usdInt * -1
-	}
-	wholeUsd := usdInt / usdCoinMultiplier
-	fractionUsd := usdInt % usdCoinMultiplier
-	fractionString := strconv.FormatInt(fractionUsd, 10)
-	if fractionUsd < 10 {
-		fractionString = "0" + fractionString
-	}
-	fractionLength := int64(utf8.RuneCountInString(fractionString))
-	wholeUsdString := strconv.FormatInt(wholeUsd, 10)
-	var buffer bytes.Buffer
-	if negative {
-		buffer.WriteString("-")
-	}
-	buffer.WriteString(wholeUsdString)
-	buffer.WriteString(usdSizeSeparator)
-	buffer.WriteString(fractionString)
-	for i := fractionLength; i < fractionsToPrint; i++ {
-		buffer.WriteString("0")
-	}
-	return buffer.String()
-}
-
-func roundUsdFromStringRepresentation(usdInt int64, fractionLength int) int64 {
-	return roundUsd(usdInt, int64(fractionLength), usdFractionSignificantDigits)
-}
-
-func roundUsd(usdInt, fractionLength, significantDigits int64) int64 {
-	roundingPlace := fractionLength - significantDigits
-	significantModulus := int64(10)
-	roundingValueMultiplier := 1
-	for i := int64(1); i <= roundingPlace; i++ {
-		currentRoundingValue := usdInt % significantModulus
-		if currentRoundingValue >= int64(roundingValueMultiplier*5) {
-			valueToAdd := significantModulus - currentRoundingValue
-			usdInt += valueToAdd
-		} else {
-			usdInt -= currentRoundingValue
-		}
-		significantModulus = significantModulus * 10
-		roundingValueMultiplier = roundingValueMultiplier * 10
-	}
-	divisor := int64(math.Pow10(int(roundingPlace)))
-	return usdInt / divisor
+// MultiplyChecked multiply, returning an OverflowError if the product does not fit in USD
+func (usd usdStruct) MultiplyChecked(value int64, fractionLength int64) (USD, error) {
+	return newUSDFromAmountChecked(usd.amount.MultiplyRounded(value, fractionLength, RoundHalfUp), "usd multiply")
 }
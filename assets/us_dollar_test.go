@@ -1,6 +1,7 @@
 package assets
 
 import (
+	"math"
 	"testing"
 )
 
@@ -48,3 +49,68 @@ func TestNegative(t *testing.T) {
 		t.Errorf("Invalid negative string value %s", negDollars.GetStringValue())
 	}
 }
+
+func TestParseWithoutDigits(t *testing.T) {
+	for _, input := range []string{"", "-", ".", "-."} {
+		if usd, err := NewUSDFromString(input); err == nil {
+			t.Errorf("Expected error parsing usd [%s] but got %s", input, usd.GetStringValue())
+		}
+		if fiat, err := NewFiatFromString(CurrencyEUR, input); err == nil {
+			t.Errorf("Expected error parsing fiat [%s] but got %s", input, fiat.GetStringValue())
+		}
+		if btc, err := NewBitcoinFromString(input); err == nil {
+			t.Errorf("Expected error parsing bitcoin [%s] but got %s", input, btc.GetStringValue())
+		}
+		if eth, err := NewEtherFromString(input); err == nil {
+			t.Errorf("Expected error parsing ether [%s] but got %s", input, eth.GetStringValue())
+		}
+	}
+}
+
+func TestUSDOverflowErrors(t *testing.T) {
+	dollars := NewUSDFromInt(math.MaxInt64)
+	if _, err := dollars.AddChecked(NewUSDFromInt(1)); err == nil {
+		t.Error("Expected overflow adding to max usd")
+	}
+	if _, err := NewUSDFromInt(math.MinInt64).SubtractChecked(NewUSDFromInt(1)); err == nil {
+		t.Error("Expected overflow subtracting from min usd")
+	}
+	if _, err := dollars.MultiplyChecked(15, 1); err == nil {
+		t.Error("Expected overflow multiplying max usd")
+	}
+	large := NewUSDFromInt(math.MaxInt64 / 2)
+	product, err := large.MultiplyChecked(15, 1)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if product.Compare(large.Multiply(15, 1)) != 0 {
+		t.Errorf("Unchecked product differs from checked product %s", product.GetStringValue())
+	}
+}
+
+func TestUSDRoundingModes(t *testing.T) {
+	halfUp, _ := NewUSDFromString("0.0449")
+	if halfUp.GetIntValue() != 4 {
+		t.Errorf("Invalid default rounding %d", halfUp.GetIntValue())
+	}
+	halfEven, _ := NewUSDFromStringRounded("2.345", RoundHalfEven)
+	if halfEven.GetIntValue() != 234 {
+		t.Errorf("Invalid half even rounding %d", halfEven.GetIntValue())
+	}
+	halfDown, _ := NewUSDFromStringRounded("2.345", RoundHalfDown)
+	if halfDown.GetIntValue() != 234 {
+		t.Errorf("Invalid half down rounding %d", halfDown.GetIntValue())
+	}
+	ceiling, _ := NewUSDFromStringRounded("2.341", RoundCeiling)
+	if ceiling.GetIntValue() != 235 {
+		t.Errorf("Invalid ceiling rounding %d", ceiling.GetIntValue())
+	}
+	dollars, _ := NewUSDFromString("10.00")
+	if dollars.MultiplyRounded(3333, 4, RoundTruncate).GetIntValue() != 333 {
+		t.Errorf("Invalid truncated multiply %d", dollars.MultiplyRounded(3333, 4, RoundTruncate).GetIntValue())
+	}
+	if product := NewUSDFromInt(100).Multiply(3, -1); product.GetStringValue() != "30.00" {
+		t.Errorf("Invalid multiply by a negative fraction length %s", product.GetStringValue())
+	}
+}
@@ -0,0 +1,104 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/petesavitsky/crypto-tools/assets"
+)
+
+// FallbackSource asks each source in turn, returning the first price found
+type FallbackSource struct {
+	sources []PriceSource
+}
+
+// NewFallbackSource create a source that falls back through the sources in order
+func NewFallbackSource(sources ...PriceSource) *FallbackSource {
+	return &FallbackSource{sources: sources}
+}
+
+// Spot the current price from the first source that has one
+func (fallback *FallbackSource) Spot(ctx context.Context, currency assets.Currency) (assets.USD, error) {
+	return fallback.first(func(source PriceSource) (assets.USD, error) {
+		return source.Spot(ctx, currency)
+	})
+}
+
+// Historical the price at a time from the first source that has one
+func (fallback *FallbackSource) Historical(ctx context.Context, currency assets.Currency, at time.Time) (assets.USD, error) {
+	return fallback.first(func(source PriceSource) (assets.USD, error) {
+		return source.Historical(ctx, currency, at)
+	})
+}
+
+func (fallback *FallbackSource) first(lookup func(PriceSource) (assets.USD, error)) (assets.USD, error) {
+	if len(fallback.sources) == 0 {
+		return nil, newSourceError("fallback", "no sources")
+	}
+	var errs []error
+	for _, source := range fallback.sources {
+		price, err := lookup(source)
+		if err == nil {
+			return price, nil
+		}
+		errs = append(errs, err)
+	}
+	return nil, errors.Join(errs...)
+}
+
+// MedianSource asks every source at once and returns the median price, ignoring sources that fail
+// as long as at least the minimum number answer
+type MedianSource struct {
+	minimum int
+	sources []PriceSource
+}
+
+// NewMedianSource create a source returning the median of the sources, a minimum below one is treated as one
+func NewMedianSource(minimum int, sources ...PriceSource) *MedianSource {
+	if minimum < 1 {
+		minimum = 1
+	}
+	return &MedianSource{minimum: minimum, sources: sources}
+}
+
+// Spot the median current price
+func (median *MedianSource) Spot(ctx context.Context, currency assets.Currency) (assets.USD, error) {
+	return median.median(func(source PriceSource) (assets.USD, error) {
+		return source.Spot(ctx, currency)
+	})
+}
+
+// Historical the median price at a time
+func (median *MedianSource) Historical(ctx context.Context, currency assets.Currency, at time.Time) (assets.USD, error) {
+	return median.median(func(source PriceSource) (assets.USD, error) {
+		return source.Historical(ctx, currency, at)
+	})
+}
+
+func (median *MedianSource) median(lookup func(PriceSource) (assets.USD, error)) (assets.USD, error) {
+	prices := make([]assets.USD, len(median.sources))
+	errs := make([]error, len(median.sources))
+	var wait sync.WaitGroup
+	for i, source := range median.sources {
+		wait.Add(1)
+		go func(i int, source PriceSource) {
+			defer wait.Done()
+			prices[i], errs[i] = lookup(source)
+		}(i, source)
+	}
+	wait.Wait()
+	var found []assets.USD
+	for i, price := range prices {
+		if errs[i] == nil {
+			found = append(found, price)
+		}
+	}
+	if len(found) < median.minimum {
+		err := newSourceError("median", strconv.Itoa(len(found))+" of "+strconv.Itoa(len(median.sources))+" sources answered, need "+strconv.Itoa(median.minimum))
+		return nil, errors.Join(append([]error{err}, errs...)...)
+	}
+	return assets.MedianUSD(found)
+}
@@ -0,0 +1,92 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/petesavitsky/crypto-tools/assets"
+)
+
+// fixed price or error for aggregator tests
+type testSource struct {
+	price string
+	err   error
+	calls int
+}
+
+func (source *testSource) Spot(ctx context.Context, currency assets.Currency) (assets.USD, error) {
+	source.calls++
+	if source.err != nil {
+		return nil, source.err
+	}
+	return assets.NewUSDFromString(source.price)
+}
+
+func (source *testSource) Historical(ctx context.Context, currency assets.Currency, at time.Time) (assets.USD, error) {
+	return source.Spot(ctx, currency)
+}
+
+var errTestSource = errors.New("source down")
+
+func TestFallbackSource(t *testing.T) {
+	down := &testSource{err: errTestSource}
+	primary := &testSource{price: "100.00"}
+	secondary := &testSource{price: "200.00"}
+	price, err := NewFallbackSource(down, primary, secondary).Spot(context.Background(), assets.CurrencyBTC)
+	if err != nil || price.GetStringValue() != "100.00" {
+		t.Errorf("Invalid fallback price %v %v", price, err)
+	}
+	if down.calls != 1 || primary.calls != 1 || secondary.calls != 0 {
+		t.Errorf("Invalid calls %d %d %d", down.calls, primary.calls, secondary.calls)
+	}
+	_, err = NewFallbackSource(down, &testSource{err: errTestSource}).Historical(context.Background(), assets.CurrencyBTC, testDay)
+	if !errors.Is(err, errTestSource) {
+		t.Errorf("Expected joined source errors, got %v", err)
+	}
+	if _, err := NewFallbackSource().Spot(context.Background(), assets.CurrencyBTC); err == nil {
+		t.Error("Expected error with no sources")
+	}
+}
+
+func TestMedianSource(t *testing.T) {
+	sources := []PriceSource{
+		&testSource{price: "100.00"},
+		&testSource{price: "130.00"},
+		&testSource{err: errTestSource},
+		&testSource{price: "101.00"},
+	}
+	price, err := NewMedianSource(2, sources...).Spot(context.Background(), assets.CurrencyETH)
+	if err != nil || price.GetStringValue() != "101.00" {
+		t.Errorf("Invalid median %v %v", price, err)
+	}
+	even, err := NewMedianSource(0, sources[:2]...).Historical(context.Background(), assets.CurrencyETH, testDay)
+	if err != nil || even.GetStringValue() != "115.00" {
+		t.Errorf("Invalid even median %v %v", even, err)
+	}
+	_, err = NewMedianSource(4, sources...).Spot(context.Background(), assets.CurrencyETH)
+	var sourceErr SourceError
+	if !errors.As(err, &sourceErr) || !errors.Is(err, errTestSource) {
+		t.Errorf("Expected too few sources error, got %v", err)
+	}
+}
+
+func TestMedianOfHTTPSources(t *testing.T) {
+	coinbase := newTestServer(t, map[string]string{"/v2/prices/BTC-USD/spot": "coinbase_btc_spot.json"})
+	kraken := newTestServer(t, map[string]string{"/0/public/Ticker?pair=XBTUSD": "kraken_ticker_xbtusd.json"})
+	coinGecko := newTestServer(t, map[string]string{"/api/v3/simple/price?ids=bitcoin&vs_currencies=usd": "coingecko_simple_bitcoin.json"})
+	source := NewMedianSource(2,
+		NewCoinbaseSource(coinbase.URL, nil),
+		NewKrakenSource(kraken.URL, nil),
+		NewCoinGeckoSource(coinGecko.URL, nil),
+	)
+	price, err := source.Spot(context.Background(), assets.CurrencyBTC)
+	if err != nil || price.GetStringValue() != "61240.12" {
+		t.Fatalf("Invalid median price %v %v", price, err)
+	}
+	bitcoin, _ := assets.NewBitcoinFromString("0.5")
+	if cost := bitcoin.GetCost(price); cost.GetStringValue() != "30620.06" {
+		t.Errorf("Invalid cost %s", cost.GetStringValue())
+	}
+}
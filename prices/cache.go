@@ -0,0 +1,154 @@
+package prices
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/petesavitsky/crypto-tools/assets"
+)
+
+// CachedSource wraps a price source, caching prices for a time to live, sharing one lookup between
+// identical requests that are in flight at once and limiting how often the wrapped source is asked.
+// Safe for concurrent use
+type CachedSource struct {
+	source        PriceSource
+	spotTTL       time.Duration
+	historicalTTL time.Duration
+	limiter       *TokenBucket
+	mutex         sync.Mutex
+	entries       map[cacheKey]cacheEntry
+	inFlight      map[cacheKey]*cacheCall
+}
+
+type cacheKey struct {
+	currency string
+	spot     bool
+	// historical unset for spot prices
+	historical time.Time
+}
+
+type cacheEntry struct {
+	price   assets.USD
+	expires time.Time
+}
+
+// a lookup in flight, done is closed once price and err are set
+type cacheCall struct {
+	done   chan struct{}
+	price  assets.USD
+	err    error
+	cancel context.CancelFunc
+	// callers still waiting, guarded by the cache mutex
+	waiters int
+}
+
+// NewCachedSource wrap a source, a time to live of zero keeps prices until Purge and a nil limiter does not limit.
+// Historical prices rarely change so usually live much longer than spot prices
+func NewCachedSource(source PriceSource, spotTTL, historicalTTL time.Duration, limiter *TokenBucket) *CachedSource {
+	return &CachedSource{
+		source:        source,
+		spotTTL:       spotTTL,
+		historicalTTL: historicalTTL,
+		limiter:       limiter,
+		entries:       map[cacheKey]cacheEntry{},
+		inFlight:      map[cacheKey]*cacheCall{},
+	}
+}
+
+// Spot the current price, from the cache while it is fresh
+func (cache *CachedSource) Spot(ctx context.Context, currency assets.Currency) (assets.USD, error) {
+	return cache.lookup(ctx, cacheKey{currency: currency.Code, spot: true}, cache.spotTTL, func(ctx context.Context) (assets.USD, error) {
+		return cache.source.Spot(ctx, currency)
+	})
+}
+
+// Historical the price at a time, from the cache while it is fresh
+func (cache *CachedSource) Historical(ctx context.Context, currency assets.Currency, at time.Time) (assets.USD, error) {
+	return cache.lookup(ctx, cacheKey{currency: currency.Code, historical: at.UTC()}, cache.historicalTTL, func(ctx context.Context) (assets.USD, error) {
+		return cache.source.Historical(ctx, currency, at)
+	})
+}
+
+// Purge drop every cached price, or only expired ones
+func (cache *CachedSource) Purge(expiredOnly bool) {
+	now := time.Now()
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	for key, entry := range cache.entries {
+		if !expiredOnly || entry.expired(now) {
+			delete(cache.entries, key)
+		}
+	}
+}
+
+func (cache *CachedSource) lookup(ctx context.Context, key cacheKey, ttl time.Duration, fetch func(context.Context) (assets.USD, error)) (assets.USD, error) {
+	cache.mutex.Lock()
+	if entry, ok := cache.entries[key]; ok && !entry.expired(time.Now()) {
+		cache.mutex.Unlock()
+		return entry.price, nil
+	}
+	call, ok := cache.inFlight[key]
+	if !ok {
+		// the shared lookup runs detached from the caller that started it so that caller giving up
+		// does not fail the others, it is only cancelled once every caller has given up
+		fetchCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &cacheCall{done: make(chan struct{}), cancel: cancel}
+		cache.inFlight[key] = call
+		go cache.run(fetchCtx, key, ttl, call, fetch)
+	}
+	call.waiters++
+	cache.mutex.Unlock()
+	return cache.wait(ctx, key, call)
+}
+
+func (cache *CachedSource) run(ctx context.Context, key cacheKey, ttl time.Duration, call *cacheCall, fetch func(context.Context) (assets.USD, error)) {
+	call.price, call.err = cache.fetch(ctx, fetch)
+	call.cancel()
+
+	cache.mutex.Lock()
+	if cache.inFlight[key] == call {
+		delete(cache.inFlight, key)
+	}
+	// errors are not cached so the next lookup tries again
+	if call.err == nil {
+		entry := cacheEntry{price: call.price}
+		if ttl > 0 {
+			entry.expires = time.Now().Add(ttl)
+		}
+		cache.entries[key] = entry
+	}
+	cache.mutex.Unlock()
+	close(call.done)
+}
+
+func (cache *CachedSource) fetch(ctx context.Context, fetch func(context.Context) (assets.USD, error)) (assets.USD, error) {
+	if cache.limiter != nil {
+		if err := cache.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return fetch(ctx)
+}
+
+// wait for the shared lookup until the caller's context is done, the result is shared including its error.
+// The last caller to give up cancels the lookup so the next one starts afresh
+func (cache *CachedSource) wait(ctx context.Context, key cacheKey, call *cacheCall) (assets.USD, error) {
+	select {
+	case <-call.done:
+		return call.price, call.err
+	case <-ctx.Done():
+		cache.mutex.Lock()
+		call.waiters--
+		if call.waiters == 0 && cache.inFlight[key] == call {
+			delete(cache.inFlight, key)
+			call.cancel()
+		}
+		cache.mutex.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+func (entry cacheEntry) expired(now time.Time) bool {
+	return !entry.expires.IsZero() && !now.Before(entry.expires)
+}
@@ -0,0 +1,170 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/petesavitsky/crypto-tools/assets"
+)
+
+// counts lookups and holds each one until release is closed
+type blockingSource struct {
+	calls   int32
+	release chan struct{}
+	err     error
+}
+
+func (source *blockingSource) Spot(ctx context.Context, currency assets.Currency) (assets.USD, error) {
+	atomic.AddInt32(&source.calls, 1)
+	if source.release != nil {
+		<-source.release
+	}
+	if source.err != nil {
+		return nil, source.err
+	}
+	return assets.NewUSDFromString("100.00")
+}
+
+func (source *blockingSource) Historical(ctx context.Context, currency assets.Currency, at time.Time) (assets.USD, error) {
+	if _, err := source.Spot(ctx, currency); err != nil {
+		return nil, err
+	}
+	return assets.NewUSDFromInt(int64(at.Day())), nil
+}
+
+func TestCachedSourceCachesUntilExpiry(t *testing.T) {
+	source := &blockingSource{}
+	cache := NewCachedSource(source, 20*time.Millisecond, 0, nil)
+	for i := 0; i < 3; i++ {
+		if price, err := cache.Spot(context.Background(), assets.CurrencyBTC); err != nil || price.GetIntValue() != 10000 {
+			t.Fatalf("Invalid price %v %v", price, err)
+		}
+	}
+	if source.calls != 1 {
+		t.Errorf("Expected one lookup, got %d", source.calls)
+	}
+	time.Sleep(30 * time.Millisecond)
+	cache.Spot(context.Background(), assets.CurrencyBTC)
+	cache.Spot(context.Background(), assets.CurrencyETH)
+	if source.calls != 3 {
+		t.Errorf("Expected expired and new currency lookups, got %d", source.calls)
+	}
+}
+
+func TestCachedSourceHistoricalKeys(t *testing.T) {
+	source := &blockingSource{}
+	cache := NewCachedSource(source, 0, 0, nil)
+	first, _ := cache.Historical(context.Background(), assets.CurrencyBTC, testDay)
+	again, _ := cache.Historical(context.Background(), assets.CurrencyBTC, testDay.In(time.FixedZone("EST", -5*3600)))
+	other, _ := cache.Historical(context.Background(), assets.CurrencyBTC, testDay.AddDate(0, 0, 1))
+	if first.GetIntValue() != 1 || again.GetIntValue() != 1 || other.GetIntValue() != 2 || source.calls != 2 {
+		t.Errorf("Invalid historical caching %d %d %d with %d calls", first.GetIntValue(), again.GetIntValue(), other.GetIntValue(), source.calls)
+	}
+	cache.Purge(true)
+	cache.Historical(context.Background(), assets.CurrencyBTC, testDay)
+	if source.calls != 2 {
+		t.Errorf("Expected entries without expiry to survive purging expired, got %d calls", source.calls)
+	}
+	cache.Purge(false)
+	cache.Historical(context.Background(), assets.CurrencyBTC, testDay)
+	if source.calls != 3 {
+		t.Errorf("Expected lookup after purge, got %d calls", source.calls)
+	}
+}
+
+func TestCachedSourceCoalesces(t *testing.T) {
+	source := &blockingSource{release: make(chan struct{})}
+	cache := NewCachedSource(source, time.Minute, time.Minute, nil)
+	var wait sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			if price, err := cache.Spot(context.Background(), assets.CurrencyBTC); err != nil || price.GetIntValue() != 10000 {
+				t.Errorf("Invalid price %v %v", price, err)
+			}
+		}()
+	}
+	// let the lookups pile up behind the first before releasing it
+	time.Sleep(20 * time.Millisecond)
+	close(source.release)
+	wait.Wait()
+	if calls := atomic.LoadInt32(&source.calls); calls != 1 {
+		t.Errorf("Expected one coalesced lookup, got %d", calls)
+	}
+}
+
+func TestCachedSourceDoesNotCacheErrors(t *testing.T) {
+	source := &blockingSource{err: errTestSource}
+	cache := NewCachedSource(source, time.Minute, time.Minute, nil)
+	for i := 0; i < 2; i++ {
+		if _, err := cache.Spot(context.Background(), assets.CurrencyBTC); !errors.Is(err, errTestSource) {
+			t.Errorf("Expected source error, got %v", err)
+		}
+	}
+	if source.calls != 2 {
+		t.Errorf("Expected errors to be retried, got %d calls", source.calls)
+	}
+}
+
+func TestCachedSourceRateLimits(t *testing.T) {
+	source := &blockingSource{}
+	cache := NewCachedSource(source, time.Minute, time.Minute, NewTokenBucket(0, 1))
+	if _, err := cache.Historical(context.Background(), assets.CurrencyBTC, testDay); err != nil {
+		t.Fatalf("Error on first lookup %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := cache.Historical(ctx, assets.CurrencyBTC, testDay.AddDate(0, 0, 1)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected limiter to block until the deadline, got %v", err)
+	}
+	if _, err := cache.Historical(context.Background(), assets.CurrencyBTC, testDay); err != nil {
+		t.Errorf("Expected cached price without a token, got %v", err)
+	}
+}
+
+func TestCachedSourceSharedLookupOutlivesFirstCaller(t *testing.T) {
+	source := &blockingSource{release: make(chan struct{})}
+	cache := NewCachedSource(source, time.Minute, time.Minute, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	first := make(chan error)
+	go func() {
+		_, err := cache.Spot(ctx, assets.CurrencyBTC)
+		first <- err
+	}()
+	// let the first caller start the lookup before another joins it
+	time.Sleep(5 * time.Millisecond)
+	second := make(chan assets.USD)
+	go func() {
+		price, err := cache.Spot(context.Background(), assets.CurrencyBTC)
+		if err != nil {
+			t.Errorf("Expected waiter to get the shared price, got %v", err)
+		}
+		second <- price
+	}()
+	if err := <-first; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected first caller to time out, got %v", err)
+	}
+	close(source.release)
+	if price := <-second; price == nil || price.GetIntValue() != 10000 {
+		t.Errorf("Invalid shared price %v", price)
+	}
+	if calls := atomic.LoadInt32(&source.calls); calls != 1 {
+		t.Errorf("Expected one shared lookup, got %d", calls)
+	}
+}
+
+func TestCachedSourceSpotAndZeroTimeKeys(t *testing.T) {
+	source := &blockingSource{}
+	cache := NewCachedSource(source, 0, 0, nil)
+	spot, _ := cache.Spot(context.Background(), assets.CurrencyBTC)
+	historical, _ := cache.Historical(context.Background(), assets.CurrencyBTC, time.Time{})
+	if spot.GetIntValue() != 10000 || historical.GetIntValue() != 1 || source.calls != 2 {
+		t.Errorf("Expected zero time historical price not to share the spot entry %d %d with %d calls", spot.GetIntValue(), historical.GetIntValue(), source.calls)
+	}
+}
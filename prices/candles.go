@@ -0,0 +1,290 @@
+package prices
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/petesavitsky/crypto-tools/assets"
+)
+
+const (
+	candleStoreSource = "candle store"
+	candleFileHeader  = "time,open,high,low,close,volume"
+)
+
+// Candle open, high, low and close USD prices over an interval starting at Time, with the volume traded in the base currency
+type Candle struct {
+	Time   time.Time
+	Open   assets.USD
+	High   assets.USD
+	Low    assets.USD
+	Close  assets.USD
+	Volume assets.Amount
+}
+
+// CandleStore time series of candles for bitcoin and ether against USD, one CSV file per pair in a directory.
+// Candles are appended in time order and read back into memory on first use. Safe for concurrent use and a
+// PriceSource, giving the close of the candle at or before a time
+type CandleStore struct {
+	directory string
+	mutex     sync.RWMutex
+	candles   map[string][]Candle
+}
+
+// OpenCandleStore open a store in the directory, creating it if needed
+func OpenCandleStore(directory string) (*CandleStore, error) {
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return nil, err
+	}
+	return &CandleStore{directory: directory, candles: map[string][]Candle{}}, nil
+}
+
+// Append add candles for the currency, each must start after the last stored candle and have a high
+// and low bounding its open and close
+func (store *CandleStore) Append(currency assets.Currency, candles ...Candle) error {
+	if err := checkCandleCurrency(currency); err != nil {
+		return err
+	}
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	stored, err := store.load(currency)
+	if err != nil {
+		return err
+	}
+	last := time.Time{}
+	if len(stored) > 0 {
+		last = stored[len(stored)-1].Time
+	}
+	for _, candle := range candles {
+		if !candle.Time.After(last) {
+			return newSourceError(candleStoreSource, "candle at "+candle.Time.UTC().Format(time.RFC3339Nano)+" is not after "+last.UTC().Format(time.RFC3339Nano))
+		}
+		if err := candle.validate(currency); err != nil {
+			return err
+		}
+		last = candle.Time
+	}
+	if err := store.write(currency, len(stored) == 0, candles); err != nil {
+		return err
+	}
+	store.candles[currency.Code] = append(stored, candles...)
+	return nil
+}
+
+// Range candles starting from from up to but not including to
+func (store *CandleStore) Range(currency assets.Currency, from, to time.Time) ([]Candle, error) {
+	stored, err := store.read(currency)
+	if err != nil {
+		return nil, err
+	}
+	start := sort.Search(len(stored), func(i int) bool { return !stored[i].Time.Before(from) })
+	end := sort.Search(len(stored), func(i int) bool { return !stored[i].Time.Before(to) })
+	if end < start {
+		end = start
+	}
+	return append([]Candle(nil), stored[start:end]...), nil
+}
+
+// ResampleRange candles from from up to to combined into intervals, see Resample
+func (store *CandleStore) ResampleRange(currency assets.Currency, from, to time.Time, interval time.Duration) ([]Candle, error) {
+	candles, err := store.Range(currency, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return Resample(candles, interval), nil
+}
+
+// PriceAt the close of the last candle starting at or before at
+func (store *CandleStore) PriceAt(currency assets.Currency, at time.Time) (assets.USD, error) {
+	stored, err := store.read(currency)
+	if err != nil {
+		return nil, err
+	}
+	index := sort.Search(len(stored), func(i int) bool { return stored[i].Time.After(at) }) - 1
+	if index < 0 {
+		return nil, newSourceError(candleStoreSource, "no "+currency.Code+" candle at or before "+at.UTC().Format(time.RFC3339))
+	}
+	return stored[index].Close, nil
+}
+
+// Spot the close of the latest candle
+func (store *CandleStore) Spot(ctx context.Context, currency assets.Currency) (assets.USD, error) {
+	stored, err := store.read(currency)
+	if err != nil {
+		return nil, err
+	}
+	if len(stored) == 0 {
+		return nil, newSourceError(candleStoreSource, "no "+currency.Code+" candles")
+	}
+	return stored[len(stored)-1].Close, nil
+}
+
+// Historical the close of the last candle starting at or before at
+func (store *CandleStore) Historical(ctx context.Context, currency assets.Currency, at time.Time) (assets.USD, error) {
+	return store.PriceAt(currency, at)
+}
+
+// Resample combine time ordered candles into candles of a longer interval, aligned to multiples of the
+// interval since midnight UTC of the first candle's day so an hour or a day start on the hour or the day
+// and a week starts at midnight of the first day. Intervals with no candles are left out
+func Resample(candles []Candle, interval time.Duration) []Candle {
+	if len(candles) == 0 {
+		return nil
+	}
+	first := candles[0].Time.UTC()
+	midnight := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, time.UTC)
+	var resampled []Candle
+	for _, candle := range candles {
+		start := midnight.Add(candle.Time.Sub(midnight) / interval * interval)
+		if len(resampled) == 0 || !resampled[len(resampled)-1].Time.Equal(start) {
+			candle.Time = start
+			resampled = append(resampled, candle)
+			continue
+		}
+		current := &resampled[len(resampled)-1]
+		if candle.High.Compare(current.High) > 0 {
+			current.High = candle.High
+		}
+		if candle.Low.Compare(current.Low) < 0 {
+			current.Low = candle.Low
+		}
+		current.Close = candle.Close
+		current.Volume, _ = current.Volume.Add(candle.Volume)
+	}
+	return resampled
+}
+
+func (candle Candle) validate(currency assets.Currency) error {
+	if candle.Open == nil || candle.High == nil || candle.Low == nil || candle.Close == nil {
+		return newSourceError(candleStoreSource, "candle at "+candle.Time.UTC().Format(time.RFC3339)+" is missing a price")
+	}
+	if candle.Volume.Currency().Code != currency.Code {
+		return newSourceError(candleStoreSource, "candle volume in ["+candle.Volume.Currency().Code+"] for ["+currency.Code+"]")
+	}
+	for _, price := range []assets.USD{candle.Open, candle.Close} {
+		if price.Compare(candle.High) > 0 || price.Compare(candle.Low) < 0 {
+			return newSourceError(candleStoreSource, "candle at "+candle.Time.UTC().Format(time.RFC3339)+" has a high or low inside its open and close")
+		}
+	}
+	return nil
+}
+
+func (store *CandleStore) read(currency assets.Currency) ([]Candle, error) {
+	if err := checkCandleCurrency(currency); err != nil {
+		return nil, err
+	}
+	store.mutex.RLock()
+	stored, ok := store.candles[currency.Code]
+	store.mutex.RUnlock()
+	if ok {
+		return stored, nil
+	}
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return store.load(currency)
+}
+
+// candles for the currency, reading the file the first time, the caller holds the write lock
+func (store *CandleStore) load(currency assets.Currency) ([]Candle, error) {
+	if stored, ok := store.candles[currency.Code]; ok {
+		return stored, nil
+	}
+	file, err := os.Open(store.path(currency))
+	if os.IsNotExist(err) {
+		store.candles[currency.Code] = nil
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var stored []Candle
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || line == candleFileHeader {
+			continue
+		}
+		candle, err := parseCandle(currency, line)
+		if err != nil {
+			return nil, err
+		}
+		stored = append(stored, candle)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	store.candles[currency.Code] = stored
+	return stored, nil
+}
+
+func (store *CandleStore) write(currency assets.Currency, header bool, candles []Candle) error {
+	file, err := os.OpenFile(store.path(currency), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(file)
+	if header {
+		if info, err := file.Stat(); err == nil && info.Size() == 0 {
+			writer.WriteString(candleFileHeader + "\n")
+		}
+	}
+	for _, candle := range candles {
+		writer.WriteString(formatCandle(candle) + "\n")
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func (store *CandleStore) path(currency assets.Currency) string {
+	return filepath.Join(store.directory, currency.Code+"-"+assets.CurrencyUSD.Code+".csv")
+}
+
+func checkCandleCurrency(currency assets.Currency) error {
+	if currency.Code != assets.CurrencyBTC.Code && currency.Code != assets.CurrencyETH.Code {
+		return newUnsupportedCurrencyError(candleStoreSource, currency)
+	}
+	return nil
+}
+
+func formatCandle(candle Candle) string {
+	return strings.Join([]string{
+		candle.Time.UTC().Format(time.RFC3339Nano),
+		candle.Open.Amount().GetStringValue(),
+		candle.High.Amount().GetStringValue(),
+		candle.Low.Amount().GetStringValue(),
+		candle.Close.Amount().GetStringValue(),
+		candle.Volume.GetStringValue(),
+	}, ",")
+}
+
+func parseCandle(currency assets.Currency, line string) (Candle, error) {
+	fields := strings.Split(line, ",")
+	if len(fields) != 6 {
+		return Candle{}, newSourceError(candleStoreSource, "invalid candle ["+line+"]")
+	}
+	at, err := time.Parse(time.RFC3339Nano, fields[0])
+	if err != nil {
+		return Candle{}, newSourceError(candleStoreSource, "invalid candle time ["+fields[0]+"]")
+	}
+	var prices [4]assets.USD
+	for i := range prices {
+		if prices[i], err = assets.NewUSDFromStringStrict(fields[i+1]); err != nil {
+			return Candle{}, err
+		}
+	}
+	volume, err := assets.ParseAmountStrict(currency, fields[5])
+	if err != nil {
+		return Candle{}, err
+	}
+	return Candle{Time: at, Open: prices[0], High: prices[1], Low: prices[2], Close: prices[3], Volume: volume}, nil
+}
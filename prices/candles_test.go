@@ -0,0 +1,169 @@
+package prices
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/petesavitsky/crypto-tools/assets"
+)
+
+func newTestCandle(t *testing.T, at time.Time, open, high, low, close, volume string) Candle {
+	prices := make([]assets.USD, 4)
+	for i, price := range []string{open, high, low, close} {
+		usd, err := assets.NewUSDFromString(price)
+		if err != nil {
+			t.Fatalf("Invalid price %s %v", price, err)
+		}
+		prices[i] = usd
+	}
+	amount, err := assets.ParseAmount(assets.CurrencyBTC, volume)
+	if err != nil {
+		t.Fatalf("Invalid volume %s %v", volume, err)
+	}
+	return Candle{Time: at, Open: prices[0], High: prices[1], Low: prices[2], Close: prices[3], Volume: amount}
+}
+
+func newTestMinuteCandles(t *testing.T) []Candle {
+	start := time.Date(2024, 3, 1, 0, 58, 0, 0, time.UTC)
+	return []Candle{
+		newTestCandle(t, start, "100.00", "105.00", "99.00", "104.00", "1.5"),
+		newTestCandle(t, start.Add(time.Minute), "104.00", "110.00", "103.00", "108.00", "0.5"),
+		newTestCandle(t, start.Add(2*time.Minute), "108.00", "109.00", "95.00", "96.00", "2"),
+		newTestCandle(t, start.Add(4*time.Minute), "96.00", "97.00", "96.00", "97.00", "0.25"),
+	}
+}
+
+func TestCandleStoreAppendAndReopen(t *testing.T) {
+	directory := t.TempDir()
+	store, err := OpenCandleStore(directory)
+	if err != nil {
+		t.Fatalf("Error opening store %v", err)
+	}
+	candles := newTestMinuteCandles(t)
+	if err := store.Append(assets.CurrencyBTC, candles[:2]...); err != nil {
+		t.Fatalf("Error appending %v", err)
+	}
+	if err := store.Append(assets.CurrencyBTC, candles[2:]...); err != nil {
+		t.Fatalf("Error appending %v", err)
+	}
+	reopened, _ := OpenCandleStore(directory)
+	stored, err := reopened.Range(assets.CurrencyBTC, candles[0].Time, candles[3].Time.Add(time.Minute))
+	if err != nil || len(stored) != 4 {
+		t.Fatalf("Expected 4 candles, got %d %v", len(stored), err)
+	}
+	last := stored[3]
+	if !last.Time.Equal(candles[3].Time) || last.Close.GetStringValue() != "97.00" || last.Volume.GetStringValue() != "0.25000000" {
+		t.Errorf("Invalid reread candle %v %v %v", last.Time, last.Close, last.Volume)
+	}
+	if stored, _ := reopened.Range(assets.CurrencyETH, candles[0].Time, candles[3].Time); len(stored) != 0 {
+		t.Errorf("Expected no ether candles, got %d", len(stored))
+	}
+}
+
+func TestCandleStoreRejectsInvalidCandles(t *testing.T) {
+	store, _ := OpenCandleStore(t.TempDir())
+	candles := newTestMinuteCandles(t)
+	store.Append(assets.CurrencyBTC, candles[1])
+	if err := store.Append(assets.CurrencyBTC, candles[0]); err == nil {
+		t.Error("Expected an out of order candle to be rejected")
+	}
+	if err := store.Append(assets.CurrencyBTC, candles[1]); err == nil {
+		t.Error("Expected a duplicate candle to be rejected")
+	}
+	inverted := candles[2]
+	inverted.High = inverted.Low
+	if err := store.Append(assets.CurrencyBTC, inverted); err == nil {
+		t.Error("Expected a high below the open to be rejected")
+	}
+	if err := store.Append(assets.CurrencyETH, candles[2]); err == nil {
+		t.Error("Expected bitcoin volume for ether to be rejected")
+	}
+	if err := store.Append(assets.CurrencyUSD, candles[2]); err == nil {
+		t.Error("Expected an unsupported currency to be rejected")
+	}
+	if stored, _ := store.Range(assets.CurrencyBTC, time.Time{}, candles[3].Time); len(stored) != 1 {
+		t.Errorf("Expected rejected candles not to be stored, got %d", len(stored))
+	}
+}
+
+func TestCandleStorePriceAt(t *testing.T) {
+	store, _ := OpenCandleStore(t.TempDir())
+	candles := newTestMinuteCandles(t)
+	store.Append(assets.CurrencyBTC, candles...)
+	tests := []struct {
+		at       time.Time
+		expected string
+	}{
+		{candles[0].Time, "104.00"},
+		{candles[1].Time.Add(30 * time.Second), "108.00"},
+		{candles[3].Time.Add(-time.Second), "96.00"},
+		{candles[3].Time.Add(time.Hour), "97.00"},
+	}
+	for _, test := range tests {
+		if price, err := store.PriceAt(assets.CurrencyBTC, test.at); err != nil || price.GetStringValue() != test.expected {
+			t.Errorf("Expected %s at %v, got %v %v", test.expected, test.at, price, err)
+		}
+	}
+	if _, err := store.PriceAt(assets.CurrencyBTC, candles[0].Time.Add(-time.Second)); err == nil {
+		t.Error("Expected no price before the first candle")
+	}
+	var source PriceSource = store
+	if price, err := source.Spot(context.Background(), assets.CurrencyBTC); err != nil || price.GetStringValue() != "97.00" {
+		t.Errorf("Expected the latest close, got %v %v", price, err)
+	}
+}
+
+func TestResample(t *testing.T) {
+	store, _ := OpenCandleStore(t.TempDir())
+	candles := newTestMinuteCandles(t)
+	store.Append(assets.CurrencyBTC, candles...)
+	hourly, err := store.ResampleRange(assets.CurrencyBTC, time.Time{}, candles[3].Time.Add(time.Minute), time.Hour)
+	if err != nil || len(hourly) != 2 {
+		t.Fatalf("Expected 2 hourly candles, got %d %v", len(hourly), err)
+	}
+	first, second := hourly[0], hourly[1]
+	if !first.Time.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) || !second.Time.Equal(time.Date(2024, 3, 1, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("Invalid hourly times %v %v", first.Time, second.Time)
+	}
+	if first.Open.GetStringValue() != "100.00" || first.High.GetStringValue() != "110.00" || first.Low.GetStringValue() != "99.00" ||
+		first.Close.GetStringValue() != "108.00" || first.Volume.GetStringValue() != "2.00000000" {
+		t.Errorf("Invalid first hour %v %v %v %v %v", first.Open, first.High, first.Low, first.Close, first.Volume)
+	}
+	if second.Open.GetStringValue() != "108.00" || second.Low.GetStringValue() != "95.00" || second.Close.GetStringValue() != "97.00" ||
+		second.Volume.GetStringValue() != "2.25000000" {
+		t.Errorf("Invalid second hour %v %v %v %v", second.Open, second.Low, second.Close, second.Volume)
+	}
+	daily := Resample(candles, 24*time.Hour)
+	if len(daily) != 1 || daily[0].High.GetStringValue() != "110.00" || daily[0].Volume.GetStringValue() != "4.25000000" {
+		t.Errorf("Invalid daily candles %v", daily)
+	}
+	weekly := Resample(candles, 7*24*time.Hour)
+	if len(weekly) != 1 || !weekly[0].Time.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected a week starting at midnight of the first day, got %v", weekly)
+	}
+	if candles[0].Time.Minute() != 58 {
+		t.Error("Expected resampling not to change its input")
+	}
+}
+
+func TestCandleStoreKeepsSubSecondTimes(t *testing.T) {
+	directory := t.TempDir()
+	store, _ := OpenCandleStore(directory)
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	candles := []Candle{
+		newTestCandle(t, start, "100.00", "100.00", "100.00", "100.00", "1"),
+		newTestCandle(t, start.Add(500*time.Millisecond), "101.00", "101.00", "101.00", "101.00", "1"),
+	}
+	if err := store.Append(assets.CurrencyBTC, candles...); err != nil {
+		t.Fatalf("Error appending %v", err)
+	}
+	reopened, err := OpenCandleStore(directory)
+	if err != nil {
+		t.Fatalf("Error reopening store %v", err)
+	}
+	stored, _ := reopened.Range(assets.CurrencyBTC, start, start.Add(time.Second))
+	if len(stored) != 2 || !stored[0].Time.Equal(candles[0].Time) || !stored[1].Time.Equal(candles[1].Time) {
+		t.Errorf("Expected sub-second candle times to survive reopening, got %v", stored)
+	}
+}
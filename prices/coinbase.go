@@ -0,0 +1,63 @@
+package prices
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/petesavitsky/crypto-tools/assets"
+)
+
+// CoinbaseURL base of the public Coinbase API
+const CoinbaseURL = "https://api.coinbase.com"
+
+const coinbaseSource = "coinbase"
+
+// CoinbaseSource prices from the Coinbase spot price endpoint, historical prices are daily
+type CoinbaseSource struct {
+	baseURL string
+	client  *http.Client
+}
+
+type coinbasePrice struct {
+	Data struct {
+		Base     string `json:"base"`
+		Currency string `json:"currency"`
+		Amount   string `json:"amount"`
+	} `json:"data"`
+}
+
+// NewCoinbaseSource create a Coinbase source, a nil client uses http.DefaultClient
+func NewCoinbaseSource(baseURL string, client *http.Client) *CoinbaseSource {
+	return &CoinbaseSource{baseURL: strings.TrimSuffix(baseURL, "/"), client: httpClient(client)}
+}
+
+// Spot the current price
+func (source *CoinbaseSource) Spot(ctx context.Context, currency assets.Currency) (assets.USD, error) {
+	return source.spot(ctx, currency, nil)
+}
+
+// Historical the price on the UTC day containing at
+func (source *CoinbaseSource) Historical(ctx context.Context, currency assets.Currency, at time.Time) (assets.USD, error) {
+	return source.spot(ctx, currency, url.Values{"date": {at.UTC().Format("2006-01-02")}})
+}
+
+func (source *CoinbaseSource) spot(ctx context.Context, currency assets.Currency, query url.Values) (assets.USD, error) {
+	if currency.Code != assets.CurrencyBTC.Code && currency.Code != assets.CurrencyETH.Code {
+		return nil, newUnsupportedCurrencyError(coinbaseSource, currency)
+	}
+	requestURL := source.baseURL + "/v2/prices/" + currency.Code + "-USD/spot"
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+	var price coinbasePrice
+	if err := getJSON(ctx, source.client, coinbaseSource, requestURL, &price); err != nil {
+		return nil, err
+	}
+	if price.Data.Base != currency.Code || price.Data.Currency != assets.CurrencyUSD.Code {
+		return nil, newSourceError(coinbaseSource, "unexpected pair ["+price.Data.Base+"-"+price.Data.Currency+"]")
+	}
+	return parseUSD(coinbaseSource, price.Data.Amount)
+}
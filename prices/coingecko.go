@@ -0,0 +1,64 @@
+package prices
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/petesavitsky/crypto-tools/assets"
+)
+
+// CoinGeckoURL base of the public CoinGecko API
+const CoinGeckoURL = "https://api.coingecko.com"
+
+const coinGeckoSource = "coingecko"
+
+var coinGeckoIDs = map[string]string{
+	assets.CurrencyBTC.Code: "bitcoin",
+	assets.CurrencyETH.Code: "ethereum",
+}
+
+// CoinGeckoSource prices from CoinGecko, historical prices are daily
+type CoinGeckoSource struct {
+	baseURL string
+	client  *http.Client
+}
+
+type coinGeckoHistory struct {
+	MarketData struct {
+		CurrentPrice map[string]json.Number `json:"current_price"`
+	} `json:"market_data"`
+}
+
+// NewCoinGeckoSource create a CoinGecko source, a nil client uses http.DefaultClient
+func NewCoinGeckoSource(baseURL string, client *http.Client) *CoinGeckoSource {
+	return &CoinGeckoSource{baseURL: strings.TrimSuffix(baseURL, "/"), client: httpClient(client)}
+}
+
+// Spot the current price
+func (source *CoinGeckoSource) Spot(ctx context.Context, currency assets.Currency) (assets.USD, error) {
+	id, ok := coinGeckoIDs[currency.Code]
+	if !ok {
+		return nil, newUnsupportedCurrencyError(coinGeckoSource, currency)
+	}
+	var prices map[string]map[string]json.Number
+	if err := getJSON(ctx, source.client, coinGeckoSource, source.baseURL+"/api/v3/simple/price?ids="+id+"&vs_currencies=usd", &prices); err != nil {
+		return nil, err
+	}
+	return parseUSD(coinGeckoSource, prices[id]["usd"].String())
+}
+
+// Historical the price CoinGecko records for the UTC day containing at
+func (source *CoinGeckoSource) Historical(ctx context.Context, currency assets.Currency, at time.Time) (assets.USD, error) {
+	id, ok := coinGeckoIDs[currency.Code]
+	if !ok {
+		return nil, newUnsupportedCurrencyError(coinGeckoSource, currency)
+	}
+	var history coinGeckoHistory
+	if err := getJSON(ctx, source.client, coinGeckoSource, source.baseURL+"/api/v3/coins/"+id+"/history?date="+at.UTC().Format("02-01-2006")+"&localization=false", &history); err != nil {
+		return nil, err
+	}
+	return parseUSD(coinGeckoSource, history.MarketData.CurrentPrice["usd"].String())
+}
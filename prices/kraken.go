@@ -0,0 +1,126 @@
+package prices
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/petesavitsky/crypto-tools/assets"
+)
+
+// KrakenURL base of the public Kraken API
+const KrakenURL = "https://api.kraken.com"
+
+const krakenSource = "kraken"
+
+// most candles the OHLC endpoint returns, older ones are not available at any interval's since
+const krakenMaxCandles = 720
+
+// kraken pair names for requests and the keys results come back under
+var krakenPairs = map[string]struct{ request, result string }{
+	assets.CurrencyBTC.Code: {request: "XBTUSD", result: "XXBTZUSD"},
+	assets.CurrencyETH.Code: {request: "ETHUSD", result: "XETHZUSD"},
+}
+
+// KrakenSource prices from the Kraken ticker, historical prices are the close of the daily candle containing the time
+type KrakenSource struct {
+	baseURL string
+	client  *http.Client
+}
+
+type krakenResponse struct {
+	Error  []string                   `json:"error"`
+	Result map[string]json.RawMessage `json:"result"`
+}
+
+type krakenTicker struct {
+	// LastTrade price and volume of the last trade
+	LastTrade []string `json:"c"`
+}
+
+// NewKrakenSource create a Kraken source, a nil client uses http.DefaultClient
+func NewKrakenSource(baseURL string, client *http.Client) *KrakenSource {
+	return &KrakenSource{baseURL: strings.TrimSuffix(baseURL, "/"), client: httpClient(client)}
+}
+
+// Spot the price of the last trade
+func (source *KrakenSource) Spot(ctx context.Context, currency assets.Currency) (assets.USD, error) {
+	pair, ok := krakenPairs[currency.Code]
+	if !ok {
+		return nil, newUnsupportedCurrencyError(krakenSource, currency)
+	}
+	var ticker krakenTicker
+	if err := source.get(ctx, "/0/public/Ticker?pair="+pair.request, pair.result, &ticker); err != nil {
+		return nil, err
+	}
+	if len(ticker.LastTrade) == 0 {
+		return nil, newSourceError(krakenSource, "missing last trade")
+	}
+	return parseUSD(krakenSource, ticker.LastTrade[0])
+}
+
+// Historical the close of the daily candle containing at. Kraken only serves its most recent 720 daily
+// candles whatever the since parameter, so days older than about two years return an error saying so
+func (source *KrakenSource) Historical(ctx context.Context, currency assets.Currency, at time.Time) (assets.USD, error) {
+	pair, ok := krakenPairs[currency.Code]
+	if !ok {
+		return nil, newUnsupportedCurrencyError(krakenSource, currency)
+	}
+	day := at.UTC().Truncate(24 * time.Hour)
+	// since is exclusive, ask from just before the day so its candle is included
+	since := strconv.FormatInt(day.Unix()-1, 10)
+	var candles [][]interface{}
+	if err := source.get(ctx, "/0/public/OHLC?pair="+pair.request+"&interval=1440&since="+since, pair.result, &candles); err != nil {
+		return nil, err
+	}
+	earliest := int64(-1)
+	for _, candle := range candles {
+		// time, open, high, low, close, vwap, volume, count
+		if len(candle) < 5 {
+			continue
+		}
+		startNumber, ok := candle[0].(json.Number)
+		if !ok {
+			continue
+		}
+		start, err := startNumber.Int64()
+		if err != nil {
+			continue
+		}
+		if earliest < 0 || start < earliest {
+			earliest = start
+		}
+		if start != day.Unix() {
+			continue
+		}
+		closePrice, _ := candle[4].(string)
+		return parseUSD(krakenSource, closePrice)
+	}
+	if earliest > day.Unix() {
+		return nil, newSourceError(krakenSource, "no candle for "+day.Format("2006-01-02")+", kraken only serves the last "+strconv.Itoa(krakenMaxCandles)+" daily candles")
+	}
+	return nil, newSourceError(krakenSource, "no candle for "+day.Format("2006-01-02"))
+}
+
+func (source *KrakenSource) get(ctx context.Context, path, resultKey string, into interface{}) error {
+	var response krakenResponse
+	if err := getJSON(ctx, source.client, krakenSource, source.baseURL+path, &response); err != nil {
+		return err
+	}
+	if len(response.Error) > 0 {
+		return newSourceError(krakenSource, strings.Join(response.Error, ", "))
+	}
+	result, ok := response.Result[resultKey]
+	if !ok {
+		return newSourceError(krakenSource, "missing result ["+resultKey+"]")
+	}
+	decoder := json.NewDecoder(strings.NewReader(string(result)))
+	decoder.UseNumber()
+	if err := decoder.Decode(into); err != nil {
+		return newSourceError(krakenSource, "invalid result -- "+err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package prices
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// TokenBucket rate limiter allowing bursts of up to burst requests and refilling at rate tokens a second
+type TokenBucket struct {
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket create a full bucket, a rate of zero or less never refills. A burst below one is raised
+// to one as the bucket could otherwise never hold a whole token
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// Allow take a token if one is available without waiting
+func (bucket *TokenBucket) Allow() bool {
+	bucket.mutex.Lock()
+	defer bucket.mutex.Unlock()
+	bucket.refill(time.Now())
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// Wait take a token, waiting for one to be added if the bucket is empty, until the context is done
+func (bucket *TokenBucket) Wait(ctx context.Context) error {
+	for {
+		wait, ok := bucket.reserve()
+		if ok {
+			return nil
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// take a token or report how long until one is available
+func (bucket *TokenBucket) reserve() (time.Duration, bool) {
+	bucket.mutex.Lock()
+	defer bucket.mutex.Unlock()
+	bucket.refill(time.Now())
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return 0, true
+	}
+	if bucket.rate <= 0 {
+		// never refills, wait out the context
+		return time.Hour, false
+	}
+	return time.Duration((1 - bucket.tokens) / bucket.rate * float64(time.Second)), false
+}
+
+func (bucket *TokenBucket) refill(now time.Time) {
+	if bucket.rate > 0 {
+		bucket.tokens += now.Sub(bucket.last).Seconds() * bucket.rate
+		if bucket.tokens > bucket.burst {
+			bucket.tokens = bucket.burst
+		}
+	}
+	bucket.last = now
+}
@@ -0,0 +1,54 @@
+package prices
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketBurst(t *testing.T) {
+	bucket := NewTokenBucket(0, 2)
+	if !bucket.Allow() || !bucket.Allow() {
+		t.Error("Expected a burst of two")
+	}
+	if bucket.Allow() {
+		t.Error("Expected an empty bucket")
+	}
+}
+
+func TestTokenBucketMinimumBurst(t *testing.T) {
+	bucket := NewTokenBucket(1000, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for i := 0; i < 2; i++ {
+		if err := bucket.Wait(ctx); err != nil {
+			t.Fatalf("Expected a burst of zero to still hand out tokens, got %v", err)
+		}
+	}
+}
+
+func TestTokenBucketRefills(t *testing.T) {
+	bucket := NewTokenBucket(100, 1)
+	bucket.Allow()
+	start := time.Now()
+	if err := bucket.Wait(context.Background()); err != nil {
+		t.Fatalf("Error waiting %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 5*time.Millisecond {
+		t.Errorf("Expected to wait for a refill, waited %v", elapsed)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if !bucket.Allow() || bucket.Allow() {
+		t.Error("Expected refills to stop at the burst size")
+	}
+}
+
+func TestTokenBucketWaitCancelled(t *testing.T) {
+	bucket := NewTokenBucket(0.001, 1)
+	bucket.Allow()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := bucket.Wait(ctx); err != context.Canceled {
+		t.Errorf("Expected cancelled wait, got %v", err)
+	}
+}
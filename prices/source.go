@@ -0,0 +1,85 @@
+// Package prices looks up USD prices of bitcoin and ether from exchange APIs or a local candle store, ready for Bitcoin.GetCost
+package prices
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/petesavitsky/crypto-tools/assets"
+)
+
+// PriceSource where USD prices come from, the price is of one whole unit of the currency
+type PriceSource interface {
+	// Spot the current price
+	Spot(ctx context.Context, currency assets.Currency) (assets.USD, error)
+	// Historical the price at a time, sources with daily data use the day containing it
+	Historical(ctx context.Context, currency assets.Currency, at time.Time) (assets.USD, error)
+}
+
+// SourceError a price source failed or returned something unusable
+type SourceError struct {
+	Source string
+	// StatusCode the HTTP status when the source answered with one other than 200
+	StatusCode int
+	message    string
+}
+
+func (err SourceError) Error() string {
+	if err.StatusCode != 0 {
+		return err.Source + " price lookup failed with status " + strconv.Itoa(err.StatusCode) + " -- " + err.message
+	}
+	return err.Source + " price lookup failed -- " + err.message
+}
+
+func newSourceError(source, message string) SourceError {
+	return SourceError{Source: source, message: message}
+}
+
+func newUnsupportedCurrencyError(source string, currency assets.Currency) SourceError {
+	return newSourceError(source, "unsupported currency ["+currency.Code+"]")
+}
+
+// get a JSON document, decoding numbers as json.Number so prices keep every digit
+func getJSON(ctx context.Context, client *http.Client, source, url string, into interface{}) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/json")
+	response, err := client.Do(request)
+	if err != nil {
+		return newSourceError(source, err.Error())
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return SourceError{Source: source, StatusCode: response.StatusCode, message: "GET " + url}
+	}
+	decoder := json.NewDecoder(response.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(into); err != nil {
+		return newSourceError(source, "invalid response -- "+err.Error())
+	}
+	return nil
+}
+
+// parse a decimal price, rounding fractions of a cent half up
+func parseUSD(source, price string) (assets.USD, error) {
+	if price == "" {
+		return nil, newSourceError(source, "missing price")
+	}
+	usd, err := assets.NewUSDFromString(price)
+	if err != nil {
+		return nil, newSourceError(source, "invalid price ["+price+"] -- "+err.Error())
+	}
+	return usd, nil
+}
+
+func httpClient(client *http.Client) *http.Client {
+	if client == nil {
+		return http.DefaultClient
+	}
+	return client
+}
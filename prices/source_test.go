@@ -0,0 +1,120 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/petesavitsky/crypto-tools/assets"
+)
+
+var testDay = time.Date(2024, 3, 1, 15, 30, 0, 0, time.UTC)
+
+// serve recorded responses keyed by request path and query, anything else is a 404
+func newTestServer(t *testing.T, responses map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		key := request.URL.Path
+		if request.URL.RawQuery != "" {
+			key += "?" + request.URL.RawQuery
+		}
+		fixture, ok := responses[key]
+		if !ok {
+			http.NotFound(writer, request)
+			return
+		}
+		body, err := os.ReadFile("testdata/" + fixture)
+		if err != nil {
+			t.Errorf("Error reading fixture %s %v", fixture, err)
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCoinbaseSource(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		"/v2/prices/BTC-USD/spot":                 "coinbase_btc_spot.json",
+		"/v2/prices/ETH-USD/spot?date=2024-03-01": "coinbase_eth_spot_2024-03-01.json",
+	})
+	source := NewCoinbaseSource(server.URL, server.Client())
+	spot, err := source.Spot(context.Background(), assets.CurrencyBTC)
+	if err != nil || spot.GetStringValue() != "61234.57" {
+		t.Errorf("Invalid spot %v %v", spot, err)
+	}
+	historical, err := source.Historical(context.Background(), assets.CurrencyETH, testDay)
+	if err != nil || historical.GetStringValue() != "3433.21" {
+		t.Errorf("Invalid historical %v %v", historical, err)
+	}
+	_, err = source.Spot(context.Background(), assets.CurrencyETH)
+	var sourceErr SourceError
+	if !errors.As(err, &sourceErr) || sourceErr.StatusCode != http.StatusNotFound || sourceErr.Source != "coinbase" {
+		t.Errorf("Expected not found source error, got %v", err)
+	}
+	if _, err := source.Spot(context.Background(), assets.CurrencyUSD); err == nil {
+		t.Error("Expected error pricing usd in usd")
+	}
+}
+
+func TestKrakenSource(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		"/0/public/Ticker?pair=XBTUSD":                              "kraken_ticker_xbtusd.json",
+		"/0/public/Ticker?pair=ETHUSD":                              "kraken_error.json",
+		"/0/public/OHLC?pair=ETHUSD&interval=1440&since=1709251199": "kraken_ohlc_ethusd.json",
+		"/0/public/OHLC?pair=ETHUSD&interval=1440&since=1708387199": "kraken_ohlc_ethusd.json",
+	})
+	source := NewKrakenSource(server.URL, nil)
+	spot, err := source.Spot(context.Background(), assets.CurrencyBTC)
+	if err != nil || spot.GetStringValue() != "61250.00" {
+		t.Errorf("Invalid spot %v %v", spot, err)
+	}
+	historical, err := source.Historical(context.Background(), assets.CurrencyETH, testDay)
+	if err != nil || historical.GetStringValue() != "3433.10" {
+		t.Errorf("Invalid historical %v %v", historical, err)
+	}
+	if _, err := source.Spot(context.Background(), assets.CurrencyETH); err == nil || err.Error() != "kraken price lookup failed -- EQuery:Unknown asset pair" {
+		t.Errorf("Expected kraken error, got %v", err)
+	}
+	if _, err := source.Historical(context.Background(), assets.CurrencyETH, testDay.AddDate(0, 0, -10)); err == nil || !strings.Contains(err.Error(), "720") {
+		t.Errorf("Expected error for a day before the candles kraken serves, got %v", err)
+	}
+	if _, err := source.Historical(context.Background(), assets.CurrencyETH, testDay.AddDate(0, 0, -20)); err == nil {
+		t.Error("Expected error for a day with no recorded candles")
+	}
+}
+
+func TestCoinGeckoSource(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		"/api/v3/simple/price?ids=bitcoin&vs_currencies=usd":                "coingecko_simple_bitcoin.json",
+		"/api/v3/coins/ethereum/history?date=01-03-2024&localization=false": "coingecko_history_ethereum.json",
+	})
+	source := NewCoinGeckoSource(server.URL+"/", server.Client())
+	spot, err := source.Spot(context.Background(), assets.CurrencyBTC)
+	if err != nil || spot.GetStringValue() != "61240.12" {
+		t.Errorf("Invalid spot %v %v", spot, err)
+	}
+	historical, err := source.Historical(context.Background(), assets.CurrencyETH, testDay)
+	if err != nil || historical.GetStringValue() != "3431.99" {
+		t.Errorf("Invalid historical %v %v", historical, err)
+	}
+	if _, err := source.Spot(context.Background(), assets.CurrencyETH); err == nil {
+		t.Error("Expected error for an unrecorded response")
+	}
+}
+
+func TestSourceHonorsContext(t *testing.T) {
+	server := newTestServer(t, map[string]string{"/v2/prices/BTC-USD/spot": "coinbase_btc_spot.json"})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewCoinbaseSource(server.URL, nil).Spot(ctx, assets.CurrencyBTC); err == nil {
+		t.Error("Expected error with a cancelled context")
+	}
+}